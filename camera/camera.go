@@ -1,9 +1,14 @@
 package camera
 
 import (
+	"math"
+
 	"github.com/rudolfkova/vectozavr/vectozavr"
 )
 
+// Pitch stays just short of the poles so the view never flips over
+const DefaultMaxPitch = vectozavr.Radians(math.Pi/2 - 0.01)
+
 type Camera struct {
 	E                 vectozavr.Vec3
 	ViewMatrix        vectozavr.Matrix
@@ -15,13 +20,68 @@ type Camera struct {
 
 	left, up, at vectozavr.Vec3
 
-	Tilt, Angle float64
-	V           vectozavr.Vec3
+	// Orientation relative to the initial basis: yaw around the world up axis,
+	// then pitch around the camera's left axis, then roll around its view axis.
+	// Left, Up and At are rebuilt from these angles on every Update.
+	Yaw, Pitch, Roll vectozavr.Radians
+	MaxPitch         vectozavr.Radians
 
-	Fov  float64
+	Fov  vectozavr.Degrees
 	Near float64
 	Far  float64
 	A    float64
+
+	// Parallel projection of a view volume OrthoHeight units tall
+	// instead of the perspective one
+	Orthographic bool
+	OrthoHeight  float64
+
+	// Built from Fov or OrthoHeight, Near, Far, the viewport and the
+	// conventions on Update
+	Projection, InverseProjection   vectozavr.Matrix
+	ScreenSpace, InverseScreenSpace vectozavr.Matrix
+	Viewport                        Viewport
+	projection                      *projectionParams
+	// Off-axis shear of the view volume of a stereo eye, see Eyes
+	shear float64
+
+	Conventions vectozavr.Conventions
+
+	Mode   Mode
+	Orbit  Orbit
+	Fly    Fly
+	Follow Follow
+	Zoom   Zoom
+
+	// Limits on the pitch and the position; nil means none
+	Constraints *Constraints
+
+	transition *transition
+}
+
+// Creates a camera at the origin looking along the forward axis of the conventions,
+// rendering into the given viewport
+func NewCamera(conv vectozavr.Conventions, vp Viewport) *Camera {
+	c := &Camera{
+		Left:        conv.SideVector(),
+		Up:          conv.UpVector(),
+		At:          conv.ForwardVector(),
+		Fov:         60,
+		Near:        1,
+		Far:         10,
+		A:           1,
+		OrthoHeight: 5,
+		MaxPitch:    DefaultMaxPitch,
+		Conventions: conv,
+		Orbit:       NewOrbit(),
+		Fly:         NewFly(),
+		Follow:      NewFollow(),
+		Zoom:        NewZoom(),
+		Viewport:    vp,
+	}
+	c.InitCamera()
+	c.Update()
+	return c
 }
 
 func ViewMatrix(left vectozavr.Vec3, up vectozavr.Vec3, at vectozavr.Vec3, e vectozavr.Vec3) vectozavr.Matrix {
@@ -57,6 +117,23 @@ func (c *Camera) InitCamera() {
 	c.at = c.At
 }
 
+// Places the camera according to its mode and rebuilds the view matrix
+// and, when its parameters changed, the projection
+func (c *Camera) Update() {
+	c.Pitch = c.Pitch.Clamp(c.pitchRange())
+	c.updateBasis()
+	if c.Mode == ModeOrbit {
+		c.applyOrbit()
+	}
+	e := c.E
+	c.constrain()
+	if c.Mode == ModeOrbit && c.E != e {
+		c.reaimOrbit()
+	}
+	c.ViewMat()
+	c.updateProjection()
+}
+
 func (c *Camera) ViewMat() {
 	c.ViewMatrix = ViewMatrix(c.Left, c.Up, c.At, c.E)
 	c.InverseViewMatrix, _ = c.ViewMatrix.Inverse()
@@ -66,17 +143,49 @@ func (c *Camera) Move(dv vectozavr.Vec3) {
 	c.E = c.E.Add(dv)
 }
 
-func (c *Camera) Rotate(tilt, angle float64) {
-	c.Vert()
-	c.Up = vectozavr.RotationV(c.Left, tilt).Vec4Mul(c.Up.ToVec4()).ToVec3()
-	c.At = vectozavr.RotationV(c.Left, tilt).Vec4Mul(c.At.ToVec4()).ToVec3()
-	c.Left = vectozavr.RotationV(c.Left, tilt).Vec4Mul(c.Left.ToVec4()).ToVec3()
+// Turns the camera by pitch (positive looks down) and yaw (positive turns left).
+// Pitch is clamped to ±MaxPitch and the range of the constraints.
+func (c *Camera) Rotate(pitch, yaw vectozavr.Radians) {
+	c.Yaw = (c.Yaw + yaw).Wrap()
+	c.Pitch = (c.Pitch + pitch).Clamp(c.pitchRange())
+	c.updateBasis()
+}
+
+// The rotation from the initial basis to the current one
+func (c *Camera) Orientation() vectozavr.Quat {
+	yaw := vectozavr.QuatAxisAngle(c.up, c.Yaw)
+	pitch := vectozavr.QuatAxisAngle(c.left, c.Pitch)
+	roll := vectozavr.QuatAxisAngle(c.at, c.Roll)
+	return yaw.Mul(pitch).Mul(roll)
+}
+
+// Sets yaw, pitch and roll from a rotation of the initial basis
+func (c *Camera) SetOrientation(q vectozavr.Quat) {
+	at := q.Rotate(c.at)
+	up := q.Rotate(c.up)
+	c.Yaw, c.Pitch = c.yawPitch(at)
+
+	// Roll is the angle from the roll-free up vector to the actual one around At
+	noRoll := vectozavr.QuatAxisAngle(c.up, c.Yaw).Mul(vectozavr.QuatAxisAngle(c.left, c.Pitch))
+	flatUp := noRoll.Rotate(c.up)
+	c.Roll = vectozavr.Radians(math.Atan2(flatUp.Cross(up).Dot(at), flatUp.Dot(up)))
+
+	c.Pitch = c.Pitch.Clamp(c.pitchRange())
+	c.updateBasis()
+}
 
-	c.Up = vectozavr.RotationV(c.V, angle).Vec4Mul(c.Up.ToVec4()).ToVec3()
-	c.At = vectozavr.RotationV(c.V, angle).Vec4Mul(c.At.ToVec4()).ToVec3()
-	c.Left = vectozavr.RotationV(c.V, angle).Vec4Mul(c.Left.ToVec4()).ToVec3()
+// Rebuilds an orthonormal Left, Up, At from the angles
+func (c *Camera) updateBasis() {
+	q := c.Orientation()
+	c.Left = q.Rotate(c.left)
+	c.Up = q.Rotate(c.up)
+	c.At = q.Rotate(c.at)
 }
 
-func (c *Camera) Vert() {
-	c.V = vectozavr.RotationV(c.Left, -c.Tilt).Vec4Mul(c.Up.ToVec4()).ToVec3()
+// Yaw and pitch of a viewing direction relative to the initial basis
+func (c *Camera) yawPitch(dir vectozavr.Vec3) (yaw, pitch vectozavr.Radians) {
+	l, u, a := dir.Dot(c.left), dir.Dot(c.up), dir.Dot(c.at)
+	pitch = vectozavr.Radians(math.Asin(math.Max(-1, math.Min(1, -u))))
+	yaw = vectozavr.Radians(math.Atan2(l, a))
+	return yaw, pitch
 }
@@ -0,0 +1,67 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_RotateNoDrift(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	// A few minutes of turning at 60 TPS
+	for i := 0; i < 20000; i++ {
+		c.Rotate(vectozavr.Radians(0.03*math.Sin(float64(i)/50)), 0.03)
+		c.Update()
+	}
+	basis := []vectozavr.Vec3{c.Left, c.Up, c.At}
+	for i, a := range basis {
+		if l, _ := a.Len(); math.Abs(l-1) > eps {
+			t.Errorf("axis %d has length %v", i, l)
+		}
+		for _, b := range basis[i+1:] {
+			if d := a.Dot(b); math.Abs(d) > eps {
+				t.Errorf("axes %v and %v are not orthogonal: %v", a, b, d)
+			}
+		}
+	}
+	// Without roll the horizon stays level
+	if d := c.Left.Dot(c.Conventions.UpVector()); math.Abs(d) > eps {
+		t.Errorf("left axis %v is tilted out of the horizon", c.Left)
+	}
+}
+
+func TestCamera_RotateClampsPitch(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Rotate(10, 0)
+	if c.Pitch != c.MaxPitch {
+		t.Errorf("pitch = %v, want %v", c.Pitch, c.MaxPitch)
+	}
+	c.Rotate(-20, 0)
+	if c.Pitch != -c.MaxPitch {
+		t.Errorf("pitch = %v, want %v", c.Pitch, -c.MaxPitch)
+	}
+}
+
+func TestCamera_SetOrientation(t *testing.T) {
+	tests := []struct {
+		name             string
+		conv             vectozavr.Conventions
+		yaw, pitch, roll vectozavr.Radians
+	}{
+		{name: "testYaw", conv: vectozavr.DefaultConventions(), yaw: 2.5},
+		{name: "testAll", conv: vectozavr.DefaultConventions(), yaw: -1, pitch: 0.6, roll: 0.3},
+		{name: "testZUp", conv: vectozavr.BlenderConventions(), yaw: 0.4, pitch: -1.2, roll: -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewCamera(tt.conv, testViewport)
+			src.Yaw, src.Pitch, src.Roll = tt.yaw, tt.pitch, tt.roll
+			c := NewCamera(tt.conv, testViewport)
+			c.SetOrientation(src.Orientation())
+			if math.Abs(float64(c.Yaw-tt.yaw)) > eps || math.Abs(float64(c.Pitch-tt.pitch)) > eps || math.Abs(float64(c.Roll-tt.roll)) > eps {
+				t.Errorf("SetOrientation() gives yaw %v, pitch %v, roll %v, want %v, %v, %v", c.Yaw, c.Pitch, c.Roll, tt.yaw, tt.pitch, tt.roll)
+			}
+		})
+	}
+}
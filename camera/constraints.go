@@ -0,0 +1,192 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Limits applied to the camera on every Update. The eye is a sphere of
+// Radius: it is pushed out of colliders, lifted above the ground and kept
+// inside Bounds, in that order. Pushing out removes the velocity towards
+// the surface, so a flying camera slides along it.
+type Constraints struct {
+	// Pitch range when LimitPitch is set, positive looks down.
+	// It never exceeds ±MaxPitch of the camera.
+	LimitPitch         bool
+	MinPitch, MaxPitch vectozavr.Radians
+
+	// The box the eye stays inside
+	Bounds *vectozavr.AABB
+
+	// The surface the eye stays MinHeight above
+	Ground    Ground
+	MinHeight float64
+
+	Radius    float64
+	Colliders []Collider
+}
+
+// Constraints with the eye radius set and everything else off
+func NewConstraints() *Constraints {
+	return &Constraints{Radius: 0.25}
+}
+
+// A surface below the camera
+type Ground interface {
+	// Height of the surface under p along the up axis
+	Height(p, up vectozavr.Vec3) float64
+}
+
+// A plane as a ground
+type GroundPlane vectozavr.Plane
+
+func (g GroundPlane) Height(p, up vectozavr.Vec3) float64 {
+	// Solve n·(p + up·(h − p·up)) = D for h
+	n := g.Normal
+	nu := n.Dot(up)
+	if math.Abs(nu) < 1e-12 {
+		return math.Inf(-1)
+	}
+	return (g.D-n.Dot(p))/nu + p.Dot(up)
+}
+
+// Height of the ground over the two world coordinates that are not the up
+// axis, in X, Y, Z order: f(x, z) for Y-up and f(x, y) for Z-up
+type Heightfield func(a, b float64) float64
+
+func (f Heightfield) Height(p, up vectozavr.Vec3) float64 {
+	switch {
+	case math.Abs(up.X) >= math.Abs(up.Y) && math.Abs(up.X) >= math.Abs(up.Z):
+		return f(p.Y, p.Z)
+	case math.Abs(up.Y) >= math.Abs(up.Z):
+		return f(p.X, p.Z)
+	}
+	return f(p.X, p.Y)
+}
+
+// Something the camera cannot pass through
+type Collider interface {
+	// Moves a sphere out of the collider the shortest way. It reports the
+	// surface normal at the contact and false when they do not touch.
+	PushOut(center vectozavr.Vec3, radius float64) (vectozavr.Vec3, vectozavr.Vec3, bool)
+}
+
+// A solid axis-aligned box
+type BoxCollider vectozavr.AABB
+
+func (b BoxCollider) PushOut(center vectozavr.Vec3, radius float64) (vectozavr.Vec3, vectozavr.Vec3, bool) {
+	box := vectozavr.AABB(b)
+	if !box.Contains(center) {
+		q := box.Clamp(center)
+		d := center.Sub(q)
+		l, _ := d.Len()
+		if l >= radius {
+			return center, vectozavr.Vec3{}, false
+		}
+		n := d.Mul(1 / l)
+		return q.Add(n.Mul(radius)), n, true
+	}
+	// Inside: leave through the nearest face
+	faces := []struct {
+		dist   float64
+		normal vectozavr.Vec3
+	}{
+		{center.X - box.Min.X, vectozavr.NewVec3(-1, 0, 0)},
+		{box.Max.X - center.X, vectozavr.NewVec3(1, 0, 0)},
+		{center.Y - box.Min.Y, vectozavr.NewVec3(0, -1, 0)},
+		{box.Max.Y - center.Y, vectozavr.NewVec3(0, 1, 0)},
+		{center.Z - box.Min.Z, vectozavr.NewVec3(0, 0, -1)},
+		{box.Max.Z - center.Z, vectozavr.NewVec3(0, 0, 1)},
+	}
+	best := faces[0]
+	for _, f := range faces[1:] {
+		if f.dist < best.dist {
+			best = f
+		}
+	}
+	return center.Add(best.normal.Mul(best.dist + radius)), best.normal, true
+}
+
+// A solid ball
+type SphereCollider struct {
+	Center vectozavr.Vec3
+	Radius float64
+}
+
+func (s SphereCollider) PushOut(center vectozavr.Vec3, radius float64) (vectozavr.Vec3, vectozavr.Vec3, bool) {
+	d := center.Sub(s.Center)
+	l, _ := d.Len()
+	if l >= s.Radius+radius {
+		return center, vectozavr.Vec3{}, false
+	}
+	n := vectozavr.NewVec3(0, 0, 1)
+	if l > 0 {
+		n = d.Mul(1 / l)
+	}
+	return s.Center.Add(n.Mul(s.Radius + radius)), n, true
+}
+
+// An object the camera bumps into, taken by its bounds
+type ObjectCollider struct {
+	Object *object.Object
+}
+
+func (o ObjectCollider) PushOut(center vectozavr.Vec3, radius float64) (vectozavr.Vec3, vectozavr.Vec3, bool) {
+	return BoxCollider(o.Object.Bounds()).PushOut(center, radius)
+}
+
+// The allowed pitch range
+func (c *Camera) pitchRange() (lo, hi vectozavr.Radians) {
+	lo, hi = -c.MaxPitch, c.MaxPitch
+	if k := c.Constraints; k != nil && k.LimitPitch {
+		lo = vectozavr.Radians(math.Max(float64(lo), float64(k.MinPitch)))
+		hi = vectozavr.Radians(math.Min(float64(hi), float64(k.MaxPitch)))
+	}
+	return lo, hi
+}
+
+// Rounds of collider resolution per update, so that a sphere wedged
+// between colliders settles
+const collisionRounds = 4
+
+// Moves the eye to the nearest allowed position
+func (c *Camera) constrain() {
+	k := c.Constraints
+	if k == nil {
+		return
+	}
+	for round := 0; round < collisionRounds; round++ {
+		touched := false
+		for _, col := range k.Colliders {
+			e, n, ok := col.PushOut(c.E, k.Radius)
+			if ok {
+				c.E = e
+				c.stopAgainst(n)
+				touched = true
+			}
+		}
+		if !touched {
+			break
+		}
+	}
+	up := c.Conventions.UpVector()
+	if k.Ground != nil {
+		floor := k.Ground.Height(c.E, up) + k.MinHeight
+		if h := c.E.Dot(up); h < floor {
+			c.E = c.E.Add(up.Mul(floor - h))
+			c.stopAgainst(up)
+		}
+	}
+	if k.Bounds != nil {
+		c.E = k.Bounds.Clamp(c.E)
+	}
+}
+
+// Drops the part of the free-fly velocity going into a surface with normal n
+func (c *Camera) stopAgainst(n vectozavr.Vec3) {
+	if v := c.Fly.Velocity.Dot(n); v < 0 {
+		c.Fly.Velocity = c.Fly.Velocity.Sub(n.Mul(v))
+	}
+}
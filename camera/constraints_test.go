@@ -0,0 +1,131 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_ConstrainGround(t *testing.T) {
+	tests := []struct {
+		name   string
+		conv   vectozavr.Conventions
+		ground Ground
+		// Height of the ground under (1, 1, 1) along the up axis
+		floor float64
+	}{
+		{name: "testPlane", conv: vectozavr.DefaultConventions(),
+			ground: GroundPlane(vectozavr.NewPlane(vectozavr.NewVec3(0, 1, 0), vectozavr.ZeroVec3())), floor: 0},
+		{name: "testSlope", conv: vectozavr.DefaultConventions(),
+			ground: GroundPlane(vectozavr.NewPlane(vectozavr.NewVec3(-1, 1, 0), vectozavr.ZeroVec3())), floor: 1},
+		{name: "testHeightfieldZUp", conv: vectozavr.BlenderConventions(),
+			ground: Heightfield(func(x, y float64) float64 { return x + 2*y }), floor: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(tt.conv, testViewport)
+			c.Constraints = NewConstraints()
+			c.Constraints.Ground = tt.ground
+			c.Constraints.MinHeight = 0.5
+			up := tt.conv.UpVector()
+			c.E = vectozavr.NewVec3(1, 1, 1).Sub(up.Mul(10))
+			c.Fly.Velocity = up.Mul(-3)
+			c.Update()
+
+			want := vectozavr.NewVec3(1, 1, 1).Sub(up).Add(up.Mul(tt.floor + 0.5))
+			if !near(c.E, want) {
+				t.Errorf("camera at %v, want %v", c.E, want)
+			}
+			if v := c.Fly.Velocity.Dot(up); v < 0 {
+				t.Errorf("camera still falls at %v", v)
+			}
+		})
+	}
+}
+
+func TestCamera_ConstrainBounds(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	box := vectozavr.NewAABB(vectozavr.NewVec3(-1, -1, -1), vectozavr.NewVec3(1, 1, 1))
+	c.Constraints = &Constraints{Bounds: &box}
+	c.E = vectozavr.NewVec3(5, 0.5, -3)
+	c.Update()
+	if want := vectozavr.NewVec3(1, 0.5, -1); !near(c.E, want) {
+		t.Errorf("camera at %v, want %v", c.E, want)
+	}
+}
+
+func TestCamera_ConstrainPitch(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Constraints = &Constraints{LimitPitch: true, MinPitch: -0.3, MaxPitch: 0.5}
+	c.Rotate(2, 0)
+	if c.Pitch != 0.5 {
+		t.Errorf("pitch = %v, want 0.5", c.Pitch)
+	}
+	c.Rotate(-4, 0)
+	if c.Pitch != -0.3 {
+		t.Errorf("pitch = %v, want -0.3", c.Pitch)
+	}
+	c.Pitch = -1
+	c.Update()
+	if c.Pitch != -0.3 {
+		t.Errorf("pitch after Update = %v, want -0.3", c.Pitch)
+	}
+}
+
+func TestCamera_CollisionSlides(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Constraints = NewConstraints()
+	wall := BoxCollider(vectozavr.NewAABB(vectozavr.NewVec3(-10, -10, 2), vectozavr.NewVec3(10, 10, 3)))
+	ball := SphereCollider{Center: vectozavr.NewVec3(0, 5, 0), Radius: 1}
+	c.Constraints.Colliders = []Collider{wall, ball}
+
+	// Fly diagonally into the wall: the camera stops at it and keeps sliding sideways
+	r := c.Constraints.Radius
+	in := FlyInput{Forward: 1, Side: 1}
+	for i := 0; i < 300; i++ {
+		c.FlyUpdate(in, 1./60)
+		c.Update()
+	}
+	if math.Abs(c.E.Z-(2-r)) > 1e-9 {
+		t.Errorf("camera at depth %v, want it against the wall at %v", c.E.Z, 2-r)
+	}
+	if c.E.X < 3 {
+		t.Errorf("camera did not slide along the wall: %v", c.E)
+	}
+	if c.Fly.Velocity.Z > 1e-9 {
+		t.Errorf("velocity into the wall %v", c.Fly.Velocity.Z)
+	}
+
+	// Teleporting inside the ball pushes the eye out
+	c.E = vectozavr.NewVec3(0, 5.2, 0)
+	c.Update()
+	if d, _ := c.E.Sub(ball.Center).Len(); math.Abs(d-(1+r)) > 1e-9 {
+		t.Errorf("camera %v from the ball centre, want %v", d, 1+r)
+	}
+}
+
+func TestCamera_ConstrainOrbit(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Constraints = NewConstraints()
+	c.Constraints.Ground = GroundPlane(vectozavr.NewPlane(vectozavr.NewVec3(0, 1, 0), vectozavr.ZeroVec3()))
+	c.Constraints.MinHeight = 0.5
+	c.SetMode(ModeOrbit)
+	c.Orbit.Target = vectozavr.NewVec3(0, 1, 0)
+	// Looking up at the target from under the ground
+	c.Pitch = -0.8
+	c.Update()
+
+	if h := c.E.Y; h < 0.5-eps {
+		t.Fatalf("camera at height %v, below the ground", h)
+	}
+	if !near(c.E.Add(c.At.Mul(c.Orbit.Distance)), c.Orbit.Target) {
+		t.Errorf("camera at %v looking %v from %v does not look at the target %v", c.E, c.At, c.Orbit.Distance, c.Orbit.Target)
+	}
+	// The next update keeps the camera where the constraints put it
+	e := c.E
+	c.Update()
+	if !near(c.E, e) {
+		t.Errorf("camera moved from %v to %v", e, c.E)
+	}
+}
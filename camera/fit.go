@@ -0,0 +1,71 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// The pose that shows every point from the current direction, with margin
+// as a fraction of the view left free around them. For a perspective
+// camera it moves back along the view axis until all points fit the field
+// of view; for an orthographic one it sizes the view volume instead.
+// The pose raises Far when the points reach past it. The second result is
+// the distance from the camera to the centre of the points, the new orbit
+// distance.
+func (c *Camera) FitPose(points []vectozavr.Vec3, margin float64) (Pose, float64) {
+	box := vectozavr.NewAABB(points...)
+	pose := c.Pose()
+	if box.Empty() {
+		return pose, c.Orbit.Distance
+	}
+	center := box.Center()
+	size, _ := box.Size().Len()
+	if size == 0 {
+		// A single point: keep the zoom and just look at it
+		pose.Position = center.Sub(c.At.Mul(c.Orbit.Distance))
+		return pose, c.Orbit.Distance
+	}
+
+	tanY := (c.Fov / 2).Radians().Tan()
+	tanX := tanY * c.A
+	k := 1 + margin
+	var d, halfW, halfH float64
+	// The nearest distance that keeps every point in front of the near plane
+	for _, p := range points {
+		v := p.Sub(center)
+		x, y, z := math.Abs(v.Dot(c.Left))*k, math.Abs(v.Dot(c.Up))*k, v.Dot(c.At)
+		d = math.Max(d, c.Near-z)
+		if c.Orthographic {
+			halfW, halfH = math.Max(halfW, x), math.Max(halfH, y)
+		} else {
+			d = math.Max(d, math.Max(x/tanX, y/tanY)-z)
+		}
+	}
+	if c.Orthographic {
+		pose.OrthoHeight = 2 * math.Max(halfH, halfW/c.A)
+		// Step back far enough for the whole box to be in front of the camera
+		d = math.Max(d, c.Near+size/2)
+	}
+	pose.Position = center.Sub(c.At.Mul(d))
+	pose.Far = math.Max(c.Far, d+size)
+	return pose, d
+}
+
+// Frames the points over duration seconds, see FitPose
+func (c *Camera) FitPoints(points []vectozavr.Vec3, margin, duration float64) {
+	if len(points) == 0 {
+		return
+	}
+	pose, d := c.FitPose(points, margin)
+	c.Orbit.Distance = d
+	c.GoTo(pose, duration)
+}
+
+// Frames the box over duration seconds, see FitPose
+func (c *Camera) FitBox(box vectozavr.AABB, margin, duration float64) {
+	if box.Empty() {
+		return
+	}
+	c.FitPoints(box.Corners(), margin, duration)
+}
@@ -0,0 +1,81 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_FitBox(t *testing.T) {
+	box := vectozavr.NewAABB(vectozavr.NewVec3(-3, 1, 2), vectozavr.NewVec3(5, 2, 9))
+	tests := []struct {
+		name         string
+		orthographic bool
+		view         View
+	}{
+		{name: "testPerspective", view: ViewFront},
+		{name: "testPerspectiveIsometric", view: ViewIsometric},
+		{name: "testOrthographic", orthographic: true, view: ViewFront},
+		{name: "testOrthographicTop", orthographic: true, view: ViewTop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+			c.Far = 100
+			c.SetOrthographic(tt.orthographic)
+			c.ShowView(tt.view, 0)
+			c.FitBox(box, 0.1, 0)
+			c.Update()
+
+			// Every corner is on screen and in front of the near plane,
+			// and at least one touches the margin
+			var maxX, maxY float64
+			for _, p := range box.Corners() {
+				v := c.Projection.Vec4Mul(c.ViewMatrix.Vec4Mul(p.ToVec4()))
+				v, _ = v.Div(v.W)
+				if v.Z < -eps {
+					t.Errorf("corner %v is behind the near plane: %v", p, v)
+				}
+				maxX, maxY = math.Max(maxX, math.Abs(v.X)), math.Max(maxY, math.Abs(v.Y))
+			}
+			if maxX > 1/1.1+1e-6 || maxY > 1/1.1+1e-6 {
+				t.Errorf("box reaches %v, %v, beyond the margin", maxX, maxY)
+			}
+			if math.Max(maxX, maxY) < 1/1.1-1e-6 {
+				t.Errorf("box reaches %v, %v, the view is not tight", maxX, maxY)
+			}
+			if !near(c.pivot(), box.Center()) {
+				t.Errorf("pivot = %v, want the centre %v", c.pivot(), box.Center())
+			}
+		})
+	}
+}
+
+func TestCamera_SetOrthographicKeepsScale(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	p := c.pivot().Add(c.Up)
+	before := c.Project(p)
+	c.SetOrthographic(true)
+	c.Update()
+	if got := c.Project(p); math.Abs(got.Y-before.Y) > 1e-6 {
+		t.Errorf("point at the pivot moved from %v to %v", before.Y, got.Y)
+	}
+	c.SetOrthographic(false)
+	c.Update()
+	if got := c.Project(p); math.Abs(got.Y-before.Y) > 1e-6 {
+		t.Errorf("point at the pivot moved from %v to %v after switching back", before.Y, got.Y)
+	}
+}
+
+func TestCamera_FitBoxRaisesFar(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	box := vectozavr.NewAABB(vectozavr.NewVec3(-20, -20, -20), vectozavr.NewVec3(20, 20, 20))
+	c.FitBox(box, 0.1, 0)
+	c.Update()
+	for _, p := range box.Corners() {
+		if z := c.ViewMatrix.Vec4Mul(p.ToVec4()).Z; z > c.Far {
+			t.Errorf("corner %v at depth %v is beyond Far = %v", p, z, c.Far)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// What the player asks the free-fly camera to do in one tick.
+// Each axis is in [-1, 1].
+type FlyInput struct {
+	Forward float64 // along the view direction projected on the horizon
+	Side    float64 // positive moves left
+	Lift    float64 // along the world up axis
+
+	Pitch float64 // positive looks down
+	Yaw   float64 // positive turns left
+	Roll  float64
+
+	Fast bool // the speed modifier is held
+}
+
+// Inertial free-fly controller. Velocities approach the requested ones
+// exponentially and are integrated exactly over each time step, so the
+// motion does not depend on the tick rate.
+type Fly struct {
+	Speed     float64           // units per second
+	TurnSpeed vectozavr.Radians // radians per second for pitch and yaw
+	RollSpeed vectozavr.Radians // radians per second
+	FastScale float64           // speed multiplier while Fast is held
+
+	// Rates in 1/s at which velocities reach the requested value while
+	// a key is held (Acceleration) and decay to zero after it is released
+	// (Damping). Zero means the velocity changes instantly.
+	Acceleration float64
+	Damping      float64
+
+	Velocity                     vectozavr.Vec3
+	PitchRate, YawRate, RollRate vectozavr.Radians
+}
+
+// A controller with speeds close to the old per-tick steps at 60 TPS
+func NewFly() Fly {
+	return Fly{
+		Speed:        2.5,
+		TurnSpeed:    1.8,
+		RollSpeed:    0.6,
+		FastScale:    4,
+		Acceleration: 10,
+		Damping:      6,
+	}
+}
+
+// Advances the free-fly camera by dt seconds
+func (c *Camera) FlyUpdate(in FlyInput, dt float64) {
+	f := &c.Fly
+	if dt <= 0 {
+		return
+	}
+	scale := 1.0
+	if in.Fast {
+		scale = f.FastScale
+	}
+
+	// Horizontal movement: drop the component along the world up axis
+	up := c.Conventions.UpVector()
+	horizontal := func(v vectozavr.Vec3) vectozavr.Vec3 {
+		h, err := v.Sub(up.Mul(v.Dot(up))).Normalize()
+		if err != nil {
+			return vectozavr.ZeroVec3()
+		}
+		return h
+	}
+	dir := horizontal(c.At).Mul(in.Forward).
+		Add(horizontal(c.Left).Mul(in.Side)).
+		Add(up.Mul(in.Lift))
+	// Diagonals are not faster than straight lines
+	if l, _ := dir.Len(); l > 1 {
+		dir = dir.Mul(1 / l)
+	}
+	target := dir.Mul(f.Speed * scale)
+
+	var dx vectozavr.Vec3
+	f.Velocity, dx = approachVec3(f.Velocity, target, f.rate(target != vectozavr.ZeroVec3()), dt)
+	c.Move(dx)
+
+	var pitch, yaw, roll vectozavr.Radians
+	f.PitchRate, pitch = f.approachAngle(f.PitchRate, f.TurnSpeed*vectozavr.Radians(in.Pitch*scale), dt)
+	f.YawRate, yaw = f.approachAngle(f.YawRate, f.TurnSpeed*vectozavr.Radians(in.Yaw*scale), dt)
+	f.RollRate, roll = f.approachAngle(f.RollRate, f.RollSpeed*vectozavr.Radians(in.Roll*scale), dt)
+	c.Rotate(pitch, yaw)
+	c.Roll = (c.Roll + roll).Wrap()
+	// Do not keep pushing into the pitch limit
+	lo, hi := c.pitchRange()
+	if c.Pitch == hi && f.PitchRate > 0 || c.Pitch == lo && f.PitchRate < 0 {
+		f.PitchRate = 0
+	}
+}
+
+// Stops all motion at once
+func (c *Camera) FlyStop() {
+	c.Fly.Velocity = vectozavr.ZeroVec3()
+	c.Fly.PitchRate, c.Fly.YawRate, c.Fly.RollRate = 0, 0, 0
+}
+
+func (f *Fly) rate(active bool) float64 {
+	if active {
+		return f.Acceleration
+	}
+	return f.Damping
+}
+
+func (f *Fly) approachAngle(v, target vectozavr.Radians, dt float64) (vectozavr.Radians, vectozavr.Radians) {
+	v1, dx := approach(float64(v), float64(target), f.rate(target != 0), dt)
+	return vectozavr.Radians(v1), vectozavr.Radians(dx)
+}
+
+// Solves v' = rate·(target − v) over dt and returns the new velocity and
+// the distance travelled
+func approach(v, target, rate, dt float64) (float64, float64) {
+	if rate <= 0 {
+		return target, target * dt
+	}
+	e := math.Exp(-rate * dt)
+	diff := v - target
+	return target + diff*e, target*dt + diff*(1-e)/rate
+}
+
+func approachVec3(v, target vectozavr.Vec3, rate, dt float64) (vectozavr.Vec3, vectozavr.Vec3) {
+	vx, dx := approach(v.X, target.X, rate, dt)
+	vy, dy := approach(v.Y, target.Y, rate, dt)
+	vz, dz := approach(v.Z, target.Z, rate, dt)
+	return vectozavr.NewVec3(vx, vy, vz), vectozavr.NewVec3(dx, dy, dz)
+}
@@ -0,0 +1,76 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Holds the input for a second, releases it for a second and returns the camera
+func flyFor(tps int, in FlyInput) *Camera {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	dt := 1 / float64(tps)
+	for i := 0; i < 2*tps; i++ {
+		if i == tps {
+			in = FlyInput{}
+		}
+		c.FlyUpdate(in, dt)
+		c.Update()
+	}
+	return c
+}
+
+func TestCamera_FlyTickRateIndependent(t *testing.T) {
+	tests := []struct {
+		name string
+		in   FlyInput
+	}{
+		{name: "testForward", in: FlyInput{Forward: 1, Side: -1, Lift: 0.5}},
+		{name: "testFast", in: FlyInput{Forward: 1, Fast: true}},
+		{name: "testTurn", in: FlyInput{Yaw: 1, Pitch: -0.5, Roll: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := flyFor(60, tt.in)
+			for _, tps := range []int{30, 144} {
+				got := flyFor(tps, tt.in)
+				if !near(got.E, want.E) {
+					t.Errorf("%d TPS: position %v, want %v", tps, got.E, want.E)
+				}
+				if math.Abs(float64(got.Yaw-want.Yaw)) > eps || math.Abs(float64(got.Pitch-want.Pitch)) > eps ||
+					math.Abs(float64(got.Roll-want.Roll)) > eps {
+					t.Errorf("%d TPS: angles %v %v %v, want %v %v %v", tps,
+						got.Yaw, got.Pitch, got.Roll, want.Yaw, want.Pitch, want.Roll)
+				}
+			}
+		})
+	}
+}
+
+func TestCamera_FlyInertia(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.FlyUpdate(FlyInput{Forward: 1}, 0.01)
+	// Starts gradually
+	if l, _ := c.Fly.Velocity.Len(); l <= 0 || l >= c.Fly.Speed/2 {
+		t.Errorf("speed after 10ms = %v", l)
+	}
+	for i := 0; i < 300; i++ {
+		c.FlyUpdate(FlyInput{Forward: 1}, 0.01)
+	}
+	if l, _ := c.Fly.Velocity.Len(); math.Abs(l-c.Fly.Speed) > 1e-6 {
+		t.Errorf("top speed = %v, want %v", l, c.Fly.Speed)
+	}
+	// Coasts after the key is released and then stops
+	e := c.E
+	c.FlyUpdate(FlyInput{}, 0.01)
+	if near(c.E, e) {
+		t.Error("camera stopped instantly")
+	}
+	for i := 0; i < 1000; i++ {
+		c.FlyUpdate(FlyInput{}, 0.01)
+	}
+	if l, _ := c.Fly.Velocity.Len(); l > 1e-6 {
+		t.Errorf("speed after release = %v", l)
+	}
+}
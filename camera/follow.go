@@ -0,0 +1,121 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Chase controller: the camera trails an object at an offset in the
+// object's local frame and looks at it
+type Follow struct {
+	Target *object.Object
+	// Offset of the camera along the target's X, Y and Z axes in the world,
+	// the GetX, GetY and GetZ axes of an object without a parent
+	Offset vectozavr.Vec3
+
+	// Time constants in seconds: how long the camera and its aim point
+	// take to cover about two thirds of the way to where they should be
+	Lag    float64
+	AimLag float64
+	// Seconds of the target's velocity the camera aims ahead
+	LookAhead float64
+	// Time constant right after switching targets; it eases back to Lag
+	RetargetTime float64
+
+	tracking bool
+	prev     vectozavr.Vec3 // target position on the previous update
+	velocity vectozavr.Vec3 // estimated target velocity
+	aim      vectozavr.Vec3 // the point the camera looks at
+	retarget float64        // seconds left of the switch
+}
+
+// Behind and above the target, with a short lag
+func NewFollow() Follow {
+	return Follow{
+		Offset:       vectozavr.NewVec3(0, 2, -5),
+		Lag:          0.3,
+		AimLag:       0.1,
+		LookAhead:    0.5,
+		RetargetTime: 1,
+	}
+}
+
+// Starts following the object, or stops following on nil. Switching from
+// another object or from another mode moves the camera smoothly.
+func (c *Camera) FollowObject(o *object.Object) {
+	f := &c.Follow
+	if o == nil {
+		f.Target = nil
+		if c.Mode == ModeFollow {
+			c.SetMode(ModeFreeFly)
+		}
+		return
+	}
+	if c.Mode != ModeFollow {
+		c.SetMode(ModeFollow)
+		f.aim = c.E.Add(c.At.Mul(c.Orbit.Distance))
+		f.retarget = f.RetargetTime
+	} else if o != f.Target {
+		f.retarget = f.RetargetTime
+	}
+	f.Target = o
+	f.tracking = false
+}
+
+// Moves the camera after its target by dt seconds
+func (c *Camera) FollowUpdate(dt float64) {
+	f := &c.Follow
+	if c.Mode != ModeFollow || f.Target == nil || dt <= 0 {
+		return
+	}
+	world := f.Target.World()
+	pos := world.W()
+	if !f.tracking {
+		f.prev, f.velocity, f.tracking = pos, vectozavr.ZeroVec3(), true
+	}
+	f.velocity = pos.Sub(f.prev).Mul(1 / dt)
+	f.prev = pos
+
+	eye := pos.Add(localAxis(world.X(), 0).Mul(f.Offset.X)).
+		Add(localAxis(world.Y(), 1).Mul(f.Offset.Y)).
+		Add(localAxis(world.Z(), 2).Mul(f.Offset.Z))
+	aim := pos.Add(f.velocity.Mul(f.LookAhead))
+
+	lag, aimLag := f.Lag, f.AimLag
+	if f.retarget > 0 && f.RetargetTime > 0 {
+		// Ease from the slow switch back to the usual lag
+		k := f.retarget / f.RetargetTime
+		lag = lag + (f.RetargetTime-lag)*k
+		aimLag = aimLag + (f.RetargetTime-aimLag)*k
+		f.retarget -= dt
+	}
+	c.E = smooth(c.E, eye, lag, dt)
+	f.aim = smooth(f.aim, aim, aimLag, dt)
+
+	if dir, err := f.aim.Sub(c.E).Normalize(); err == nil {
+		c.Yaw, c.Pitch = c.yawPitch(dir)
+		c.Roll = 0
+		c.Pitch = c.Pitch.Clamp(c.pitchRange())
+		c.updateBasis()
+	}
+}
+
+// Moves from a towards b as if by exponential decay with the time
+// constant tau over dt; tau of zero jumps to b
+func smooth(a, b vectozavr.Vec3, tau, dt float64) vectozavr.Vec3 {
+	if tau <= 0 {
+		return b
+	}
+	return a.Add(b.Sub(a).Mul(1 - math.Exp(-dt/tau)))
+}
+
+// Normalises an axis of an object's frame; a degenerate one falls back to the world axis
+func localAxis(v vectozavr.Vec3, i int) vectozavr.Vec3 {
+	n, err := v.Normalize()
+	if err != nil {
+		return [3]vectozavr.Vec3{vectozavr.NewVec3(1, 0, 0), vectozavr.NewVec3(0, 1, 0), vectozavr.NewVec3(0, 0, 1)}[i]
+	}
+	return n
+}
@@ -0,0 +1,95 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// An object at the point, turned by a around the world Y axis
+func testObject(p vectozavr.Vec3, a vectozavr.Radians) *object.Object {
+	o := object.NewObject(vectozavr.RotationY(a))
+	o.TranslateToPoint(p)
+	return o
+}
+
+func TestCamera_FollowOffset(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	o := testObject(vectozavr.NewVec3(3, 0, 1), math.Pi/2)
+	c.FollowObject(o)
+	for i := 0; i < 600; i++ {
+		c.FollowUpdate(1. / 60)
+		c.Update()
+	}
+	// Behind along the object's Z axis and above along its Y axis
+	want := o.GetPos().Add(o.GetY().Mul(2)).Add(o.GetZ().Mul(-5))
+	if !near(c.E, want) {
+		t.Errorf("camera at %v, want %v", c.E, want)
+	}
+	dir, _ := o.GetPos().Sub(c.E).Normalize()
+	if !near(c.At, dir) {
+		t.Errorf("camera looks along %v, want %v", c.At, dir)
+	}
+}
+
+func TestCamera_FollowLagAndLookAhead(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	o := testObject(vectozavr.ZeroVec3(), 0)
+	c.FollowObject(o)
+	for i := 0; i < 600; i++ {
+		c.FollowUpdate(1. / 60)
+	}
+	c.Follow.retarget = 0
+
+	// A jump of the target is followed gradually
+	e := c.E
+	o.Translate(vectozavr.NewVec3(1, 0, 0))
+	c.FollowUpdate(1. / 60)
+	if moved := c.E.X - e.X; moved <= 0 || moved > 0.1 {
+		t.Errorf("camera moved %v after the target jumped by 1", moved)
+	}
+
+	// Moving steadily, the camera aims ahead of the target
+	v := vectozavr.NewVec3(0, 0, 2)
+	for i := 0; i < 600; i++ {
+		o.Translate(v.Mul(1. / 60))
+		c.FollowUpdate(1. / 60)
+	}
+	want, _ := o.GetPos().Add(v.Mul(c.Follow.LookAhead)).Sub(c.E).Normalize()
+	if d := c.At.Dot(want); math.Abs(d-1) > 1e-3 {
+		t.Errorf("camera looks along %v, want %v", c.At, want)
+	}
+}
+
+func TestCamera_FollowRetarget(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	a := testObject(vectozavr.ZeroVec3(), 0)
+	b := testObject(vectozavr.NewVec3(20, 0, 0), 0)
+	c.FollowObject(a)
+	for i := 0; i < 600; i++ {
+		c.FollowUpdate(1. / 60)
+	}
+
+	// Right after the switch the camera moves slower than its usual lag allows
+	c.FollowObject(b)
+	e := c.E
+	c.FollowUpdate(1. / 60)
+	step, _ := c.E.Sub(e).Len()
+	usual := 20 * (1 - math.Exp(-1./60/c.Follow.Lag))
+	if step <= 0 || step >= usual/2 {
+		t.Errorf("first step after the switch is %v, usual lag gives %v", step, usual)
+	}
+	for i := 0; i < 600; i++ {
+		c.FollowUpdate(1. / 60)
+	}
+	if want := b.GetPos().Add(vectozavr.NewVec3(0, 2, -5)); !near(c.E, want) {
+		t.Errorf("camera at %v, want %v", c.E, want)
+	}
+
+	c.FollowObject(nil)
+	if c.Mode != ModeFreeFly {
+		t.Errorf("mode after stopping = %v", c.Mode)
+	}
+}
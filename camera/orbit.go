@@ -0,0 +1,119 @@
+package camera
+
+import (
+	"fmt"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// How the camera is controlled
+type Mode int
+
+const (
+	// Flying with WASD and arrow keys, see Fly
+	ModeFreeFly Mode = iota
+	// Turning around a target point with the mouse
+	ModeOrbit
+	// Trailing an object, see Follow
+	ModeFollow
+)
+
+var modeNames = [...]string{"freefly", "orbit", "follow"}
+
+func (m Mode) String() string {
+	if m < 0 || int(m) >= len(modeNames) {
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+	return modeNames[m]
+}
+
+// Encodes the mode by its name
+func (m Mode) MarshalText() ([]byte, error) {
+	if m < 0 || int(m) >= len(modeNames) {
+		return nil, fmt.Errorf("unknown camera mode %d", int(m))
+	}
+	return []byte(modeNames[m]), nil
+}
+
+func (m *Mode) UnmarshalText(text []byte) error {
+	for i, n := range modeNames {
+		if n == string(text) {
+			*m = Mode(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown camera mode %q", text)
+}
+
+// Turntable controller: the camera keeps its yaw and pitch and looks at
+// Target from Distance away
+type Orbit struct {
+	Target   vectozavr.Vec3
+	Distance float64
+
+	RotateSpeed vectozavr.Radians // radians per dragged pixel
+	PanSpeed    float64           // fraction of Distance per dragged pixel
+
+	MinDistance, MaxDistance float64
+}
+
+// An orbit with the default speeds and limits
+func NewOrbit() Orbit {
+	return Orbit{
+		Distance:    5,
+		RotateSpeed: 0.005,
+		PanSpeed:    0.002,
+		MinDistance: 0.1,
+		MaxDistance: 1000,
+	}
+}
+
+// Switches the control mode. Entering the orbit mode keeps the current view:
+// the target is placed Orbit.Distance ahead of the camera. Free-fly motion
+// stops on every switch.
+func (c *Camera) SetMode(m Mode) {
+	c.FlyStop()
+	if m == ModeOrbit && c.Mode != ModeOrbit {
+		if c.Orbit.RotateSpeed == 0 {
+			c.Orbit = NewOrbit()
+		}
+		c.Orbit.Target = c.E.Add(c.At.Mul(c.Orbit.Distance))
+	}
+	c.Mode = m
+}
+
+// Turns the orbit by a mouse drag in pixels
+func (c *Camera) OrbitRotate(dx, dy float64) {
+	speed := c.Orbit.RotateSpeed
+	c.Rotate(vectozavr.Radians(dy)*speed, -vectozavr.Radians(dx)*speed)
+}
+
+// Moves the target in the view plane by a mouse drag in pixels,
+// so the scene follows the cursor
+func (c *Camera) OrbitPan(dx, dy float64) {
+	o := &c.Orbit
+	k := o.Distance * o.PanSpeed
+	if c.Conventions.Handedness == vectozavr.LeftHanded {
+		dx = -dx
+	}
+	o.Target = o.Target.Add(c.Left.Mul(dx * k)).Add(c.Up.Mul(dy * k))
+}
+
+// Places the camera on the orbit; the basis must be up to date
+func (c *Camera) applyOrbit() {
+	c.E = c.Orbit.Target.Sub(c.At.Mul(c.Orbit.Distance))
+}
+
+// Turns a camera the constraints pushed off its orbit back to the target,
+// which it now sees from its new distance
+func (c *Camera) reaimOrbit() {
+	to := c.Orbit.Target.Sub(c.E)
+	d, _ := to.Len()
+	dir, err := to.Normalize()
+	if err != nil {
+		return
+	}
+	c.Yaw, c.Pitch = c.yawPitch(dir)
+	c.updateBasis()
+	c.Orbit.Distance = d
+}
@@ -0,0 +1,58 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+const eps = 1e-9
+
+func near(a, b vectozavr.Vec3) bool {
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps && math.Abs(a.Z-b.Z) < eps
+}
+
+func TestCamera_SetModeKeepsView(t *testing.T) {
+	tests := []struct {
+		name string
+		conv vectozavr.Conventions
+	}{
+		{name: "testYUp", conv: vectozavr.DefaultConventions()},
+		{name: "testZUp", conv: vectozavr.BlenderConventions()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(tt.conv, testViewport)
+			c.E = vectozavr.NewVec3(1, 2, 3)
+			c.Rotate(0.4, 0.7)
+			e, at := c.E, c.At
+
+			c.SetMode(ModeOrbit)
+			c.Update()
+			if !near(c.E, e) || !near(c.At, at) {
+				t.Errorf("orbit pose = %v looking %v, want %v looking %v", c.E, c.At, e, at)
+			}
+			if d, _ := c.Orbit.Target.Sub(c.E).Len(); math.Abs(d-c.Orbit.Distance) > eps {
+				t.Errorf("distance to target = %v, want %v", d, c.Orbit.Distance)
+			}
+		})
+	}
+}
+
+func TestCamera_OrbitRotate(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.SetMode(ModeOrbit)
+	target := c.Orbit.Target
+	c.OrbitRotate(300, 10000)
+	c.Update()
+	if c.Pitch != c.MaxPitch {
+		t.Errorf("pitch = %v, want it clamped to %v", c.Pitch, c.MaxPitch)
+	}
+	if !near(c.E.Add(c.At.Mul(c.Orbit.Distance)), target) {
+		t.Errorf("camera at %v looking %v does not look at the target %v", c.E, c.At, target)
+	}
+	if l, _ := c.Left.Len(); math.Abs(l-1) > eps || math.Abs(c.Left.Dot(c.At)) > eps {
+		t.Errorf("basis is not orthonormal: left %v, at %v", c.Left, c.At)
+	}
+}
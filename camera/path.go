@@ -0,0 +1,180 @@
+package camera
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A camera pose at a moment of a path, in seconds
+type Keyframe struct {
+	Time float64 `json:"time"`
+	Pose
+}
+
+// A sequence of keyframes played back with spline-interpolated positions,
+// slerped orientations and linearly interpolated field of view
+type Path struct {
+	Keyframes []Keyframe `json:"keyframes"`
+	// Playback restarts from the first keyframe after the last one
+	Loop bool `json:"loop"`
+}
+
+// Inserts a keyframe keeping the keyframes ordered by time
+func (p *Path) Add(k Keyframe) {
+	i := sort.Search(len(p.Keyframes), func(i int) bool { return p.Keyframes[i].Time > k.Time })
+	p.Keyframes = append(p.Keyframes, Keyframe{})
+	copy(p.Keyframes[i+1:], p.Keyframes[i:])
+	p.Keyframes[i] = k
+}
+
+// Adds the current pose of the camera at the given time
+func (p *Path) Record(c *Camera, t float64) {
+	p.Add(Keyframe{Time: t, Pose: c.Pose()})
+}
+
+// Time of the last keyframe
+func (p *Path) Duration() float64 {
+	if len(p.Keyframes) == 0 {
+		return 0
+	}
+	return p.Keyframes[len(p.Keyframes)-1].Time
+}
+
+// Returns the interpolated pose at time t. Outside the keyframes the first or
+// the last pose is held, or the time wraps around when the path loops.
+func (p *Path) Sample(t float64) Pose {
+	keys := p.Keyframes
+	switch len(keys) {
+	case 0:
+		return Pose{Orientation: vectozavr.IdentityQuat()}
+	case 1:
+		return keys[0].Pose
+	}
+	start, end := keys[0].Time, keys[len(keys)-1].Time
+	if p.Loop && end > start {
+		t = start + math.Mod(t-start, end-start)
+		if t < start {
+			t += end - start
+		}
+	}
+	if t <= start {
+		return keys[0].Pose
+	}
+	if t >= end {
+		return keys[len(keys)-1].Pose
+	}
+
+	i := sort.Search(len(keys), func(i int) bool { return keys[i].Time > t }) - 1
+	k0, k1 := keys[i], keys[i+1]
+	dt := k1.Time - k0.Time
+	u := (t - k0.Time) / dt
+
+	return Pose{
+		Position:    vectozavr.Hermite(k0.Position, p.tangent(i).Mul(dt), k1.Position, p.tangent(i+1).Mul(dt), u),
+		Orientation: vectozavr.Slerp(k0.Orientation, k1.Orientation, u),
+		Fov:         k0.Fov + (k1.Fov-k0.Fov)*vectozavr.Degrees(u),
+		OrthoHeight: k0.OrthoHeight + (k1.OrthoHeight-k0.OrthoHeight)*u,
+	}
+}
+
+// Velocity at keyframe i: a central difference inside, one-sided at the ends
+func (p *Path) tangent(i int) vectozavr.Vec3 {
+	keys := p.Keyframes
+	a, b := i-1, i+1
+	if a < 0 {
+		a = 0
+	}
+	if b >= len(keys) {
+		b = len(keys) - 1
+	}
+	dt := keys[b].Time - keys[a].Time
+	if dt <= 0 {
+		return vectozavr.ZeroVec3()
+	}
+	return keys[b].Position.Sub(keys[a].Position).Mul(1 / dt)
+}
+
+// Writes the path as JSON
+func (p *Path) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("cannot save camera path: %v", err)
+	}
+	return nil
+}
+
+// Reads a path written by Save
+func LoadPath(r io.Reader) (*Path, error) {
+	var p Path
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("cannot load camera path: %v", err)
+	}
+	sort.SliceStable(p.Keyframes, func(i, j int) bool { return p.Keyframes[i].Time < p.Keyframes[j].Time })
+	return &p, nil
+}
+
+// Plays a path back on a camera
+type Player struct {
+	Path    *Path
+	Time    float64
+	Speed   float64 // playback rate, 1 is real time
+	Playing bool
+}
+
+// Creates a paused player at the start of the path
+func NewPlayer(p *Path) *Player {
+	return &Player{Path: p, Speed: 1}
+}
+
+func (pl *Player) Play() {
+	if !pl.Path.Loop && pl.Time >= pl.Path.Duration() {
+		pl.Time = 0
+	}
+	pl.Playing = true
+}
+
+func (pl *Player) Pause() {
+	pl.Playing = false
+}
+
+func (pl *Player) Toggle() {
+	if pl.Playing {
+		pl.Pause()
+	} else {
+		pl.Play()
+	}
+}
+
+// Jumps to the given time, clamped to the path unless it loops
+func (pl *Player) Scrub(t float64) {
+	if !pl.Path.Loop {
+		t = math.Max(0, math.Min(pl.Path.Duration(), t))
+	}
+	pl.Time = t
+}
+
+// Advances playback by dt seconds; a non-looping path stops at its end
+func (pl *Player) Update(dt float64) {
+	if !pl.Playing {
+		return
+	}
+	pl.Time += dt * pl.Speed
+	if !pl.Path.Loop && pl.Time >= pl.Path.Duration() {
+		pl.Time = pl.Path.Duration()
+		pl.Playing = false
+	}
+}
+
+// Moves the camera to the pose at the current time
+func (pl *Player) Apply(c *Camera) {
+	if len(pl.Path.Keyframes) == 0 {
+		return
+	}
+	c.SetPose(pl.Path.Sample(pl.Time))
+}
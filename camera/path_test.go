@@ -0,0 +1,97 @@
+package camera
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func testPath() *Path {
+	p := &Path{}
+	yaw := vectozavr.NewVec3(0, 1, 0)
+	p.Add(Keyframe{Time: 2, Pose: Pose{Position: vectozavr.NewVec3(2, 0, 0), Orientation: vectozavr.QuatAxisAngle(yaw, 1), Fov: 90}})
+	p.Add(Keyframe{Time: 0, Pose: Pose{Position: vectozavr.NewVec3(0, 0, 0), Orientation: vectozavr.IdentityQuat(), Fov: 60}})
+	p.Add(Keyframe{Time: 1, Pose: Pose{Position: vectozavr.NewVec3(1, 0, 0), Orientation: vectozavr.QuatAxisAngle(yaw, 0.5), Fov: 75}})
+	return p
+}
+
+func TestPath_Sample(t *testing.T) {
+	p := testPath()
+	tests := []struct {
+		name string
+		t    float64
+		pos  vectozavr.Vec3
+		fov  vectozavr.Degrees
+	}{
+		{name: "testBefore", t: -1, pos: vectozavr.NewVec3(0, 0, 0), fov: 60},
+		{name: "testKey", t: 1, pos: vectozavr.NewVec3(1, 0, 0), fov: 75},
+		{name: "testBetween", t: 1.5, pos: vectozavr.NewVec3(1.5, 0, 0), fov: 82.5},
+		{name: "testAfter", t: 3, pos: vectozavr.NewVec3(2, 0, 0), fov: 90},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := p.Sample(tt.t)
+			if !near(got.Position, tt.pos) {
+				t.Errorf("Sample().Position = %v, want %v", got.Position, tt.pos)
+			}
+			if math.Abs(float64(got.Fov-tt.fov)) > eps {
+				t.Errorf("Sample().Fov = %v, want %v", got.Fov, tt.fov)
+			}
+		})
+	}
+
+	want := vectozavr.QuatAxisAngle(vectozavr.NewVec3(0, 1, 0), 0.75)
+	if got := p.Sample(1.5).Orientation; math.Abs(math.Abs(got.Dot(want))-1) > eps {
+		t.Errorf("Sample().Orientation = %v, want %v", got, want)
+	}
+}
+
+func TestPath_Loop(t *testing.T) {
+	p := testPath()
+	p.Loop = true
+	if got, want := p.Sample(2.5).Position, p.Sample(0.5).Position; !near(got, want) {
+		t.Errorf("Sample(2.5) = %v, want %v", got, want)
+	}
+	if got, want := p.Sample(-0.5).Position, p.Sample(1.5).Position; !near(got, want) {
+		t.Errorf("Sample(-0.5) = %v, want %v", got, want)
+	}
+}
+
+func TestPath_SaveLoad(t *testing.T) {
+	p := testPath()
+	var buf bytes.Buffer
+	if err := p.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPath(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Keyframes) != len(p.Keyframes) {
+		t.Fatalf("LoadPath() has %d keyframes, want %d", len(got.Keyframes), len(p.Keyframes))
+	}
+	for i := range p.Keyframes {
+		if got.Keyframes[i] != p.Keyframes[i] {
+			t.Errorf("keyframe %d = %v, want %v", i, got.Keyframes[i], p.Keyframes[i])
+		}
+	}
+}
+
+func TestPlayer_StopsAtEnd(t *testing.T) {
+	pl := NewPlayer(testPath())
+	pl.Play()
+	for i := 0; i < 300; i++ {
+		pl.Update(1. / 60)
+	}
+	if pl.Playing || pl.Time != 2 {
+		t.Errorf("player at %v, playing %v; want stopped at 2", pl.Time, pl.Playing)
+	}
+
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	pl.Apply(c)
+	if !near(c.E, vectozavr.NewVec3(2, 0, 0)) || c.Fov != 90 {
+		t.Errorf("camera at %v with fov %v", c.E, c.Fov)
+	}
+}
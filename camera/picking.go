@@ -0,0 +1,58 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Returns the world-space ray through the pixel of the viewport. A
+// perspective ray starts at the camera; orthographic rays are parallel
+// to the view axis and start in the plane of the camera.
+func (c *Camera) RayFromScreen(x, y float64, vp Viewport) vectozavr.Ray {
+	m := c.matricesFor(vp)
+	ndc := m.inverseScreenSpace.Vec4Mul(vectozavr.NewVec4(x, y, 0, 1))
+	// Any depth inside the view volume lies on the ray, 0.5 does for both depth ranges
+	v := m.inverseProjection.Vec4Mul(vectozavr.NewVec4(ndc.X, ndc.Y, 0.5, 1))
+	v, _ = v.Div(v.W)
+	if c.Orthographic {
+		origin := c.InverseViewMatrix.Vec4Mul(vectozavr.NewVec4(v.X, v.Y, 0, 1)).ToVec3()
+		return vectozavr.NewRay(origin, c.At)
+	}
+	// The camera looks along +Z in view space
+	return vectozavr.NewRay(c.E, c.InverseViewMatrix.Vec3Mul(v.ToVec3()))
+}
+
+// Projects a world point to the pixel of the viewport. The flag reports
+// whether the point lies inside the view volume: in the viewport and
+// between the near and the far planes. Points behind a perspective camera
+// have no meaningful pixel.
+func (c *Camera) WorldToScreen(p vectozavr.Vec3, vp Viewport) (vectozavr.Vec2, bool) {
+	m := c.matricesFor(vp)
+	v := c.ViewMatrix.Vec4Mul(p.ToVec4())
+	clip := m.projection.Vec4Mul(v)
+	if clip.W <= 0 {
+		return vectozavr.Vec2{}, false
+	}
+	ndc, _ := clip.Div(clip.W)
+	s := m.screenSpace.Vec4Mul(ndc)
+	visible := v.Z >= c.Near && v.Z <= c.Far && math.Abs(ndc.X) <= 1 && math.Abs(ndc.Y) <= 1
+	return vectozavr.NewVec2(s.X, s.Y), visible
+}
+
+// Cuts the segment between two world points to the part in front of the
+// near plane, clipping in view space. The flag is false when the whole
+// segment is behind it.
+func (c *Camera) ClipNear(a, b vectozavr.Vec3) (vectozavr.Vec3, vectozavr.Vec3, bool) {
+	za := c.ViewMatrix.Vec4Mul(a.ToVec4()).Z
+	zb := c.ViewMatrix.Vec4Mul(b.ToVec4()).Z
+	switch {
+	case za < c.Near && zb < c.Near:
+		return a, b, false
+	case za < c.Near:
+		a = a.Add(b.Sub(a).Mul((c.Near - za) / (zb - za)))
+	case zb < c.Near:
+		b = b.Add(a.Sub(b).Mul((c.Near - zb) / (za - zb)))
+	}
+	return a, b, true
+}
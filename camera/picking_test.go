@@ -0,0 +1,87 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_RayFromScreen(t *testing.T) {
+	vp := Viewport{X: 100, Y: 50, Width: 400, Height: 200}
+	for name, conv := range map[string]vectozavr.Conventions{
+		"default": vectozavr.DefaultConventions(),
+		"blender": vectozavr.BlenderConventions(),
+		"unity":   vectozavr.UnityConventions(),
+		"opengl":  vectozavr.OpenGLConventions(),
+	} {
+		for _, ortho := range []bool{false, true} {
+			c := NewCamera(conv, testViewport)
+			c.Orthographic = ortho
+			c.E = vectozavr.NewVec3(1, 2, -3)
+			c.Rotate(0.3, -0.7)
+			c.Update()
+
+			p := c.E.Add(c.At.Mul(5)).Add(c.Left.Mul(1)).Add(c.Up.Mul(-0.5))
+			s, ok := c.WorldToScreen(p, vp)
+			if !ok {
+				t.Errorf("%s, orthographic %v: %v is not visible", name, ortho, p)
+				continue
+			}
+			ray := c.RayFromScreen(s.X, s.Y, vp)
+			// The ray passes through the point
+			v := p.Sub(ray.Origin)
+			if miss, _ := v.Sub(ray.Direction.Mul(v.Dot(ray.Direction))).Len(); miss > 1e-9 || v.Dot(ray.Direction) <= 0 {
+				t.Errorf("%s, orthographic %v: ray %v misses %v by %v", name, ortho, ray, p, miss)
+			}
+			if ortho && math.Abs(ray.Direction.Dot(c.At)-1) > 1e-9 {
+				t.Errorf("%s: orthographic ray along %v, want %v", name, ray.Direction, c.At)
+			}
+		}
+	}
+}
+
+func TestCamera_WorldToScreen(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	tests := []struct {
+		name    string
+		p       vectozavr.Vec3
+		visible bool
+	}{
+		{name: "testCenter", p: vectozavr.NewVec3(0, 0, 5), visible: true},
+		{name: "testBehind", p: vectozavr.NewVec3(0, 0, -5)},
+		{name: "testBeyondFar", p: vectozavr.NewVec3(0, 0, 20)},
+		{name: "testAside", p: vectozavr.NewVec3(10, 0, 5)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, ok := c.WorldToScreen(tt.p, c.Viewport)
+			if ok != tt.visible {
+				t.Errorf("visible = %v, want %v", ok, tt.visible)
+			}
+			if ok {
+				if want := c.Project(tt.p); math.Abs(s.X-want.X) > 1e-9 || math.Abs(s.Y-want.Y) > 1e-9 {
+					t.Errorf("WorldToScreen = %v, Project = %v", s, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCamera_ClipNear(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Update()
+	ahead := c.E.Add(c.At.Mul(5))
+	behind := c.E.Sub(c.At.Mul(5)).Add(c.Left)
+
+	a, b, ok := c.ClipNear(ahead, behind)
+	if !ok || a != ahead {
+		t.Fatalf("ClipNear() = %v, %v, %v, want the point ahead kept", a, b, ok)
+	}
+	if z := c.ViewMatrix.Vec4Mul(b.ToVec4()).Z; math.Abs(z-c.Near) > eps {
+		t.Errorf("clipped end at depth %v, want the near plane %v", z, c.Near)
+	}
+	if _, _, ok := c.ClipNear(behind, behind.Add(c.Up)); ok {
+		t.Errorf("a segment behind the camera is not rejected")
+	}
+}
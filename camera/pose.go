@@ -0,0 +1,40 @@
+package camera
+
+import (
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Where the camera is, where it looks and how wide
+type Pose struct {
+	Position    vectozavr.Vec3    `json:"position"`
+	Orientation vectozavr.Quat    `json:"orientation"`
+	Fov         vectozavr.Degrees `json:"fov"`
+	// Height of the view volume of an orthographic camera
+	OrthoHeight float64 `json:"orthoHeight,omitempty"`
+	// Far clipping distance the pose needs; zero keeps the camera's
+	Far float64 `json:"far,omitempty"`
+}
+
+// Returns the current pose of the camera
+func (c *Camera) Pose() Pose {
+	return Pose{Position: c.E, Orientation: c.Orientation(), Fov: c.Fov, OrthoHeight: c.OrthoHeight}
+}
+
+// Moves the camera to the pose. In the orbit mode the target is moved
+// so that the camera keeps looking at it from the same distance.
+func (c *Camera) SetPose(p Pose) {
+	c.E = p.Position
+	c.SetOrientation(p.Orientation)
+	if p.Fov > 0 {
+		c.Fov = p.Fov
+	}
+	if p.OrthoHeight > 0 {
+		c.OrthoHeight = p.OrthoHeight
+	}
+	if p.Far > c.Near {
+		c.Far = p.Far
+	}
+	if c.Mode == ModeOrbit {
+		c.Orbit.Target = c.E.Add(c.At.Mul(c.Orbit.Distance))
+	}
+}
@@ -0,0 +1,157 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A rectangle of the screen the camera renders into, in pixels
+type Viewport struct {
+	X, Y          int
+	Width, Height int
+}
+
+// Width divided by height
+func (v Viewport) Aspect() float64 {
+	if v.Height == 0 {
+		return 1
+	}
+	return float64(v.Width) / float64(v.Height)
+}
+
+// Reports whether the viewport has no pixels, as hidden panes have
+func (v Viewport) Empty() bool {
+	return v.Width <= 0 || v.Height <= 0
+}
+
+// Reports whether the pixel lies inside the viewport
+func (v Viewport) Contains(x, y int) bool {
+	return x >= v.X && y >= v.Y && x < v.X+v.Width && y < v.Y+v.Height
+}
+
+// Parameters the cached projection matrices were built from
+type projectionParams struct {
+	fov          vectozavr.Degrees
+	orthographic bool
+	orthoHeight  float64
+	shear        float64
+	near, far    float64
+	viewport     Viewport
+	conv         vectozavr.Conventions
+}
+
+func (c *Camera) projectionParams() projectionParams {
+	return projectionParams{
+		fov:          c.Fov,
+		orthographic: c.Orthographic,
+		orthoHeight:  c.OrthoHeight,
+		shear:        c.shear,
+		near:         c.Near,
+		far:          c.Far,
+		viewport:     c.Viewport,
+		conv:         c.Conventions,
+	}
+}
+
+// Sets the screen rectangle the camera renders into and rebuilds the
+// projection if it changed
+func (c *Camera) SetViewport(vp Viewport) {
+	c.Viewport = vp
+	c.updateProjection()
+}
+
+// Rebuilds the projection and screen-space matrices if any of their
+// parameters changed since the last build. An empty viewport has no
+// screen space, so the old matrices are kept until it gets pixels again.
+func (c *Camera) updateProjection() {
+	p := c.projectionParams()
+	if (c.projection != nil && *c.projection == p) || c.Viewport.Empty() {
+		return
+	}
+	c.projection = &p
+	c.A = c.Viewport.Aspect()
+	m := c.screenMatrices(c.Viewport)
+	c.Projection, c.InverseProjection = m.projection, m.inverseProjection
+	c.ScreenSpace, c.InverseScreenSpace = m.screenSpace, m.inverseScreenSpace
+}
+
+// Projection and screen-space matrices with their inverses
+type screenMatrices struct {
+	projection, inverseProjection   vectozavr.Matrix
+	screenSpace, inverseScreenSpace vectozavr.Matrix
+}
+
+// The matrices for rendering into the viewport: the cached ones for the
+// camera's own viewport, brought up to date, or new ones for another
+func (c *Camera) matricesFor(vp Viewport) screenMatrices {
+	if vp != c.Viewport {
+		return c.screenMatrices(vp)
+	}
+	c.updateProjection()
+	return screenMatrices{
+		projection: c.Projection, inverseProjection: c.InverseProjection,
+		screenSpace: c.ScreenSpace, inverseScreenSpace: c.InverseScreenSpace,
+	}
+}
+
+// Builds the matrices of the camera's projection rendering into the viewport
+func (c *Camera) screenMatrices(vp Viewport) screenMatrices {
+	var m screenMatrices
+	conv, a := c.Conventions, vp.Aspect()
+	if c.Orthographic {
+		m.projection = conv.Orthographic(c.OrthoHeight, a, c.Near, c.Far)
+		m.inverseProjection = conv.InverseOrthographic(c.OrthoHeight, a, c.Near, c.Far)
+	} else {
+		m.projection = conv.Projection(c.Fov, a, c.Near, c.Far)
+		m.inverseProjection = conv.InverseProjection(c.Fov, a, c.Near, c.Far)
+	}
+	if c.shear != 0 {
+		m.projection = m.projection.MatMul(shearX(c.shear))
+		m.inverseProjection = shearX(-c.shear).MatMul(m.inverseProjection)
+	}
+
+	w, h := float64(vp.Width), float64(vp.Height)
+	offset := vectozavr.NewVec3(float64(vp.X), float64(vp.Y), 0)
+	m.screenSpace = vectozavr.Translation(offset).MatMul(conv.ScreenSpace(w, h))
+	m.inverseScreenSpace = conv.InverseScreenSpace(w, h).MatMul(vectozavr.Translation(offset.Mul(-1)))
+	return m
+}
+
+// Switches between the perspective and the orthographic projection,
+// keeping the size of the scene at the pivot the same
+func (c *Camera) SetOrthographic(on bool) {
+	if on == c.Orthographic {
+		return
+	}
+	pivot := c.pivot()
+	tan := (c.Fov / 2).Radians().Tan()
+	if on {
+		c.OrthoHeight = 2 * pivot.Sub(c.E).Dot(c.At) * tan
+	} else {
+		// Step back so the perspective view is as wide at the pivot
+		o := &c.Orbit
+		o.Distance = math.Max(o.MinDistance, math.Min(o.MaxDistance, c.OrthoHeight/(2*tan)))
+		c.E = pivot.Sub(c.At.Mul(o.Distance))
+	}
+	c.Orthographic = on
+	c.updateProjection()
+}
+
+// Projects a world point to screen coordinates; Z holds the normalised depth
+func (c *Camera) Project(p vectozavr.Vec3) vectozavr.Vec4 {
+	v := c.ViewMatrix.Vec4Mul(p.ToVec4())
+	v = c.Projection.Vec4Mul(v)
+	v, _ = v.Div(v.W)
+	return c.ScreenSpace.Vec4Mul(v)
+}
+
+// Shifts view-space X by k·Z
+func shearX(k float64) vectozavr.Matrix {
+	return vectozavr.NewMatrix([4][4]float64{
+		{1, 0, k, 0},
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
@@ -0,0 +1,72 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+var testViewport = Viewport{Width: 800, Height: 600}
+
+func TestCamera_SetViewport(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	p := c.Projection
+	c.SetViewport(Viewport{X: 100, Y: 50, Width: 400, Height: 200})
+	if c.A != 2 {
+		t.Errorf("aspect = %v, want 2", c.A)
+	}
+	if c.Projection == p {
+		t.Errorf("projection was not rebuilt for the new viewport")
+	}
+	if got := c.InverseScreenSpace.MatMul(c.ScreenSpace); got != vectozavr.Identity() {
+		t.Errorf("InverseScreenSpace·ScreenSpace = %v, want identity", got)
+	}
+}
+
+func TestCamera_SetViewportEmpty(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	screen, inverse := c.ScreenSpace, c.InverseScreenSpace
+	c.SetViewport(Viewport{})
+	c.Update()
+	if c.ScreenSpace != screen || c.InverseScreenSpace != inverse {
+		t.Errorf("matrices were rebuilt for an empty viewport: %v, %v", c.ScreenSpace, c.InverseScreenSpace)
+	}
+	c.SetViewport(Viewport{Width: 400, Height: 200})
+	if c.A != 2 {
+		t.Errorf("aspect = %v after the viewport came back, want 2", c.A)
+	}
+}
+
+func TestCamera_Project(t *testing.T) {
+	tests := []struct {
+		name  string
+		vp    Viewport
+		p     vectozavr.Vec3
+		wantX float64
+		wantY float64
+	}{
+		{name: "testCenter", vp: testViewport, p: vectozavr.NewVec3(0, 0, 5), wantX: 400, wantY: 300},
+		{name: "testOffsetViewport", vp: Viewport{X: 100, Y: 50, Width: 400, Height: 200}, p: vectozavr.NewVec3(0, 0, 5), wantX: 300, wantY: 150},
+		// Right-handed: +X is on the left of the screen, +Y at the top
+		{name: "testLeftUp", vp: testViewport, p: vectozavr.NewVec3(1, 1, 5), wantX: 400 - 400/(math.Tan(math.Pi/6)*800.0/600.0)/5, wantY: 300 - 300/math.Tan(math.Pi/6)/5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(vectozavr.DefaultConventions(), tt.vp)
+			got := c.Project(tt.p)
+			if math.Abs(got.X-tt.wantX) > 1e-6 || math.Abs(got.Y-tt.wantY) > 1e-6 {
+				t.Errorf("Project(%v) = (%v, %v), want (%v, %v)", tt.p, got.X, got.Y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestCamera_UpdateRebuildsProjection(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Fov = 90
+	c.Update()
+	if want := c.Conventions.Projection(90, c.A, c.Near, c.Far); c.Projection != want {
+		t.Errorf("projection = %v, want %v", c.Projection, want)
+	}
+}
@@ -0,0 +1,108 @@
+package camera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Everything needed to put a camera back where it was: the pose, the
+// projection parameters and the control mode
+type State struct {
+	Pose
+	Near         float64 `json:"near"`
+	Far          float64 `json:"far"`
+	Orthographic bool    `json:"orthographic"`
+
+	Mode Mode `json:"mode"`
+	// The orbit target lies this far ahead of the camera
+	OrbitDistance float64 `json:"orbitDistance"`
+
+	// The orientation is relative to the initial basis of these conventions
+	Conventions vectozavr.Conventions `json:"conventions"`
+}
+
+// Returns the current state of the camera. The followed object is not
+// part of it: a following camera is saved as a free-flying one.
+func (c *Camera) State() State {
+	mode := c.Mode
+	if mode == ModeFollow {
+		mode = ModeFreeFly
+	}
+	return State{
+		Pose:          c.Pose(),
+		Near:          c.Near,
+		Far:           c.Far,
+		Orthographic:  c.Orthographic,
+		Mode:          mode,
+		OrbitDistance: c.Orbit.Distance,
+		Conventions:   c.Conventions,
+	}
+}
+
+// Restores a saved state. States saved with other conventions are refused:
+// their orientation is relative to a different basis.
+func (c *Camera) SetState(s State) error {
+	if err := c.GoToState(s, 0); err != nil {
+		return err
+	}
+	c.Update()
+	return nil
+}
+
+// Moves the camera to a saved state over duration seconds, see GoTo.
+// The projection type and the mode switch at once.
+func (c *Camera) GoToState(s State, duration float64) error {
+	if s.Conventions != c.Conventions {
+		return errors.New("camera state was saved with other conventions")
+	}
+	if s.Near > 0 && s.Far > s.Near {
+		c.Near, c.Far = s.Near, s.Far
+	}
+	c.Orthographic = s.Orthographic
+	c.SetMode(s.Mode)
+	if s.OrbitDistance > 0 {
+		c.Orbit.Distance = s.OrbitDistance
+	}
+	c.GoTo(s.Pose, duration)
+	return nil
+}
+
+// Encodes the state of the camera, see State
+func (c *Camera) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.State())
+}
+
+// Restores the state of a camera created by NewCamera
+func (c *Camera) UnmarshalJSON(data []byte) error {
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.SetState(s)
+}
+
+// Camera states saved under names, such as the presets of a scene
+type Presets map[string]State
+
+// Writes the presets as JSON
+func (p Presets) Save(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("cannot save camera presets: %v", err)
+	}
+	return nil
+}
+
+// Reads presets written by Presets.Save
+func LoadPresets(r io.Reader) (Presets, error) {
+	p := Presets{}
+	if err := json.NewDecoder(r).Decode(&p); err != nil {
+		return nil, fmt.Errorf("cannot load camera presets: %v", err)
+	}
+	return p, nil
+}
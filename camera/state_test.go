@@ -0,0 +1,69 @@
+package camera
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_JSON(t *testing.T) {
+	src := NewCamera(vectozavr.BlenderConventions(), testViewport)
+	src.E = vectozavr.NewVec3(1, -2, 3)
+	src.Rotate(0.4, -1.1)
+	src.Roll = 0.2
+	src.Fov = 45
+	src.Near, src.Far = 0.5, 50
+	src.SetOrthographic(true)
+	src.SetMode(ModeOrbit)
+	src.Orbit.Distance = 7
+	src.Update()
+
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := NewCamera(vectozavr.BlenderConventions(), testViewport)
+	if err := json.Unmarshal(data, dst); err != nil {
+		t.Fatal(err)
+	}
+	if !near(dst.E, src.E) || !near(dst.At, src.At) || !near(dst.Up, src.Up) {
+		t.Errorf("restored camera at %v looking along %v, up %v; want %v, %v, %v", dst.E, dst.At, dst.Up, src.E, src.At, src.Up)
+	}
+	if dst.Mode != ModeOrbit || !near(dst.Orbit.Target, src.Orbit.Target) || dst.Orbit.Distance != 7 {
+		t.Errorf("restored orbit %v %+v, want %v %+v", dst.Mode, dst.Orbit, src.Mode, src.Orbit)
+	}
+	if dst.Fov != 45 || dst.Near != 0.5 || dst.Far != 50 || !dst.Orthographic || math.Abs(dst.OrthoHeight-src.OrthoHeight) > eps {
+		t.Errorf("restored projection fov %v, near %v, far %v, orthographic %v, height %v",
+			dst.Fov, dst.Near, dst.Far, dst.Orthographic, dst.OrthoHeight)
+	}
+	if !bytes.Contains(data, []byte(`"mode":"orbit"`)) {
+		t.Errorf("mode is not saved by name: %s", data)
+	}
+
+	other := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	if err := json.Unmarshal(data, other); err == nil {
+		t.Error("state saved with other conventions was accepted")
+	}
+}
+
+func TestPresets_SaveLoad(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	p := Presets{"home": c.State()}
+	c.E = vectozavr.NewVec3(4, 5, 6)
+	p["away"] = c.State()
+
+	var buf bytes.Buffer
+	if err := p.Save(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadPresets(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got["away"] != p["away"] || got["home"] != p["home"] {
+		t.Errorf("LoadPresets() = %v, want %v", got, p)
+	}
+}
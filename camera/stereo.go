@@ -0,0 +1,44 @@
+package camera
+
+import (
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A pair of eyes looking along the camera's view axis. Both eyes use
+// off-axis frusta that meet at the convergence distance, so points there
+// appear at the same place for both eyes, nearer points in front of the
+// screen and farther ones behind it.
+type Stereo struct {
+	EyeSeparation float64 // distance between the eyes in world units
+	Convergence   float64 // distance of the zero-parallax plane
+}
+
+// A rig with separation and convergence suited to the default grid
+func NewStereo() Stereo {
+	return Stereo{EyeSeparation: 0.2, Convergence: 5}
+}
+
+// Returns cameras for the left and right eye rendering into the given viewports
+func (c *Camera) Eyes(s Stereo, left, right Viewport) (*Camera, *Camera) {
+	return c.eye(s, -1, left), c.eye(s, 1, right)
+}
+
+// The eye on the given side of the screen: -1 for the left one, 1 for the right one
+func (c *Camera) eye(s Stereo, side float64, vp Viewport) *Camera {
+	e := *c
+	e.transition = nil
+	e.SetViewport(vp)
+
+	// The view X axis (Left) points to the left of the screen for
+	// right-handed conventions and to the right for left-handed ones
+	sx := c.Conventions.ScreenSpace(2, 2).Vec4Mul(vectozavr.NewVec4(1, 0, 0, 0)).X
+	x := side * sx * s.EyeSeparation / 2
+	e.E = c.E.Add(c.Left.Mul(x))
+	e.ViewMat()
+
+	// Shear the view volume so that it still covers the centre camera's
+	// view at the convergence distance: x' = x + x_eye·z/Convergence
+	e.shear = x / s.Convergence
+	e.updateProjection()
+	return &e
+}
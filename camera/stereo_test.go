@@ -0,0 +1,76 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_Eyes(t *testing.T) {
+	tests := []struct {
+		name         string
+		conv         vectozavr.Conventions
+		orthographic bool
+	}{
+		{name: "testRightHanded", conv: vectozavr.DefaultConventions()},
+		{name: "testLeftHanded", conv: vectozavr.UnityConventions()},
+		{name: "testBlender", conv: vectozavr.BlenderConventions()},
+		{name: "testOrthographic", conv: vectozavr.DefaultConventions(), orthographic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(tt.conv, testViewport)
+			c.Far = 100
+			c.Orthographic = tt.orthographic
+			c.Rotate(0.2, 0.5)
+			c.Update()
+			s := NewStereo()
+			l, r := c.Eyes(s, c.Viewport, c.Viewport)
+
+			at := func(d float64) vectozavr.Vec3 {
+				return c.E.Add(c.At.Mul(d)).Add(c.Up.Mul(0.3)).Add(c.Left.Mul(0.4))
+			}
+			// At the convergence distance both eyes see the point where the centre camera does
+			p := at(s.Convergence)
+			want := c.Project(p)
+			for _, eye := range []*Camera{l, r} {
+				if got := eye.Project(p); math.Abs(got.X-want.X) > 1e-6 || math.Abs(got.Y-want.Y) > 1e-6 {
+					t.Errorf("eye sees %v at %v, want %v", p, got, want)
+				}
+			}
+			// Farther points shift right in the right eye, nearer ones left
+			if far := at(2 * s.Convergence); r.Project(far).X <= l.Project(far).X {
+				t.Errorf("far point has parallax %v", r.Project(far).X-l.Project(far).X)
+			}
+			if close := at(s.Convergence / 2); r.Project(close).X >= l.Project(close).X {
+				t.Errorf("near point has parallax %v", r.Project(close).X-l.Project(close).X)
+			}
+			v := vectozavr.NewVec4(0.3, -0.2, 4, 1)
+			if got := l.InverseProjection.Vec4Mul(l.Projection.Vec4Mul(v)); !near(got.ToVec3(), v.ToVec3()) || math.Abs(got.W-1) > eps {
+				t.Errorf("eye InverseProjection·Projection maps %v to %v", v, got)
+			}
+		})
+	}
+}
+
+func TestCamera_EyesWorldToScreen(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Far = 100
+	c.Update()
+	l, r := c.Eyes(NewStereo(), c.Viewport, c.Viewport)
+	p := c.E.Add(c.At.Mul(2)).Add(c.Left.Mul(0.3))
+	for _, eye := range []*Camera{l, r} {
+		want := eye.Project(p)
+		got, ok := eye.WorldToScreen(p, eye.Viewport)
+		if !ok || math.Abs(got.X-want.X) > 1e-9 || math.Abs(got.Y-want.Y) > 1e-9 {
+			t.Errorf("WorldToScreen = %v (%v), Project = %v", got, ok, want)
+		}
+		// The picking ray through that pixel passes through the point
+		ray := eye.RayFromScreen(got.X, got.Y, eye.Viewport)
+		v := p.Sub(ray.Origin)
+		if miss, _ := v.Sub(ray.Direction.Mul(v.Dot(ray.Direction))).Len(); miss > 1e-9 {
+			t.Errorf("eye ray misses the point by %v", miss)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package camera
+
+import (
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// An animated move between two poses
+type transition struct {
+	from, to Pose
+	duration float64
+	time     float64
+}
+
+// The point the camera turns around: the orbit target, or Orbit.Distance
+// ahead of the camera when flying
+func (c *Camera) pivot() vectozavr.Vec3 {
+	if c.Mode == ModeOrbit {
+		return c.Orbit.Target
+	}
+	return c.E.Add(c.At.Mul(c.Orbit.Distance))
+}
+
+// Moves the camera to the pose over duration seconds. On the way the
+// camera swings around its pivot instead of cutting straight through it.
+// A non-positive duration jumps at once.
+func (c *Camera) GoTo(p Pose, duration float64) {
+	c.FlyStop()
+	if p.Fov <= 0 {
+		p.Fov = c.Fov
+	}
+	if p.OrthoHeight <= 0 {
+		p.OrthoHeight = c.OrthoHeight
+	}
+	// Widen the depth range up front so nothing is clipped on the way
+	if p.Far > c.Far {
+		c.Far = p.Far
+	}
+	if duration <= 0 {
+		c.transition = nil
+		c.SetPose(p)
+		return
+	}
+	c.transition = &transition{from: c.Pose(), to: p, duration: duration}
+}
+
+// Reports whether a transition started by GoTo is running
+func (c *Camera) Animating() bool {
+	return c.transition != nil
+}
+
+// Cancels a running transition, leaving the camera where it is
+func (c *Camera) StopAnimation() {
+	c.transition = nil
+}
+
+// Advances a running transition by dt seconds
+func (c *Camera) Animate(dt float64) {
+	tr := c.transition
+	if tr == nil {
+		return
+	}
+	tr.time += dt
+	u := tr.time / tr.duration
+	if u >= 1 {
+		c.transition = nil
+		c.SetPose(tr.to)
+		return
+	}
+	// Ease in and out
+	u = u * u * (3 - 2*u)
+
+	d := c.Orbit.Distance
+	fromPivot := tr.from.Position.Add(tr.from.Orientation.Rotate(c.at).Mul(d))
+	toPivot := tr.to.Position.Add(tr.to.Orientation.Rotate(c.at).Mul(d))
+	pivot := fromPivot.Add(toPivot.Sub(fromPivot).Mul(u))
+
+	q := vectozavr.Slerp(tr.from.Orientation, tr.to.Orientation, u)
+	c.SetPose(Pose{
+		Position:    pivot.Sub(q.Rotate(c.at).Mul(d)),
+		Orientation: q,
+		Fov:         tr.from.Fov + (tr.to.Fov-tr.from.Fov)*vectozavr.Degrees(u),
+		OrthoHeight: tr.from.OrthoHeight + (tr.to.OrthoHeight-tr.from.OrthoHeight)*u,
+	})
+}
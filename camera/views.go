@@ -0,0 +1,109 @@
+package camera
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A standard view of the scene
+type View int
+
+const (
+	// Looking along the forward axis of the conventions
+	ViewFront View = iota
+	ViewBack
+	// Looking down along the up axis
+	ViewTop
+	ViewBottom
+	// Looking at the left side of the scene
+	ViewLeft
+	ViewRight
+	// Front-right-top, the diagonal of the unit cube pointing at the camera
+	ViewIsometric
+)
+
+var viewNames = [...]string{"front", "back", "top", "bottom", "left", "right", "isometric"}
+
+// All standard views in declaration order
+var Views = []View{ViewFront, ViewBack, ViewTop, ViewBottom, ViewLeft, ViewRight, ViewIsometric}
+
+func (v View) String() string {
+	if v < 0 || int(v) >= len(viewNames) {
+		return fmt.Sprintf("View(%d)", int(v))
+	}
+	return viewNames[v]
+}
+
+// Finds a standard view by its name
+func ParseView(name string) (View, error) {
+	for i, n := range viewNames {
+		if n == name {
+			return View(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown view %q", name)
+}
+
+// Elevation of the isometric view, atan(1/√2)
+var isometricPitch = vectozavr.Radians(math.Atan(1 / math.Sqrt2))
+
+// Yaw and pitch of the view relative to the initial basis. The top and
+// bottom views stop at MaxPitch.
+func (c *Camera) viewAngles(v View) (yaw, pitch vectozavr.Radians) {
+	switch v {
+	case ViewBack:
+		return math.Pi, 0
+	case ViewTop:
+		return 0, c.MaxPitch
+	case ViewBottom:
+		return 0, -c.MaxPitch
+	case ViewLeft:
+		return -math.Pi / 2, 0
+	case ViewRight:
+		return math.Pi / 2, 0
+	case ViewIsometric:
+		return math.Pi / 4, isometricPitch
+	}
+	return 0, 0
+}
+
+// The rotation of the initial basis that gives the view
+func (c *Camera) ViewOrientation(v View) vectozavr.Quat {
+	yaw, pitch := c.viewAngles(v)
+	return vectozavr.QuatAxisAngle(c.up, yaw).Mul(vectozavr.QuatAxisAngle(c.left, pitch))
+}
+
+// The direction the camera looks in the view
+func (c *Camera) ViewDirection(v View) vectozavr.Vec3 {
+	return c.ViewOrientation(v).Rotate(c.at)
+}
+
+// The pose showing the current pivot from the view, see GoTo
+func (c *Camera) ViewPose(v View) Pose {
+	q := c.ViewOrientation(v)
+	d := c.Orbit.Distance
+	return Pose{
+		Position:    c.pivot().Sub(q.Rotate(c.at).Mul(d)),
+		Orientation: q,
+		Fov:         c.Fov,
+	}
+}
+
+// Turns the camera to the view over duration seconds. A non-positive
+// duration sets the angles at once, so a camera with MaxPitch of π/2
+// looks exactly along the up axis in the top and bottom views.
+func (c *Camera) ShowView(v View, duration float64) {
+	if duration > 0 {
+		c.GoTo(c.ViewPose(v), duration)
+		return
+	}
+	c.FlyStop()
+	c.transition = nil
+	pivot := c.pivot()
+	c.Yaw, c.Pitch = c.viewAngles(v)
+	c.Roll = 0
+	c.updateBasis()
+	c.E = pivot.Sub(c.At.Mul(c.Orbit.Distance))
+}
@@ -0,0 +1,103 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_ViewDirection(t *testing.T) {
+	s := 1 / math.Sqrt(3)
+	tests := []struct {
+		name string
+		conv vectozavr.Conventions
+		view View
+		want vectozavr.Vec3
+	}{
+		{name: "testFront", conv: vectozavr.DefaultConventions(), view: ViewFront, want: vectozavr.NewVec3(0, 0, 1)},
+		{name: "testBack", conv: vectozavr.DefaultConventions(), view: ViewBack, want: vectozavr.NewVec3(0, 0, -1)},
+		{name: "testRight", conv: vectozavr.DefaultConventions(), view: ViewRight, want: vectozavr.NewVec3(1, 0, 0)},
+		{name: "testLeft", conv: vectozavr.DefaultConventions(), view: ViewLeft, want: vectozavr.NewVec3(-1, 0, 0)},
+		{name: "testIsometric", conv: vectozavr.DefaultConventions(), view: ViewIsometric, want: vectozavr.NewVec3(s, -s, s)},
+		{name: "testBlenderFront", conv: vectozavr.BlenderConventions(), view: ViewFront, want: vectozavr.NewVec3(0, 1, 0)},
+		{name: "testBlenderRight", conv: vectozavr.BlenderConventions(), view: ViewRight, want: vectozavr.NewVec3(-1, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCamera(tt.conv, testViewport)
+			if got := c.ViewDirection(tt.view); !near(got, tt.want) {
+				t.Errorf("ViewDirection(%v) = %v, want %v", tt.view, got, tt.want)
+			}
+		})
+	}
+
+	c := NewCamera(vectozavr.BlenderConventions(), testViewport)
+	if d := c.ViewDirection(ViewTop); d.Z > -0.99 {
+		t.Errorf("top view looks along %v", d)
+	}
+	if d := c.ViewDirection(ViewBottom); d.Z < 0.99 {
+		t.Errorf("bottom view looks along %v", d)
+	}
+}
+
+func TestParseView(t *testing.T) {
+	for _, v := range Views {
+		if got, err := ParseView(v.String()); err != nil || got != v {
+			t.Errorf("ParseView(%q) = %v, %v", v.String(), got, err)
+		}
+	}
+	if _, err := ParseView("sideways"); err == nil {
+		t.Error("ParseView() accepted an unknown name")
+	}
+}
+
+func TestCamera_ShowView(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.E = vectozavr.NewVec3(1, 2, 3)
+	c.Rotate(0.3, -0.4)
+	c.Update()
+	pivot := c.pivot()
+
+	c.ShowView(ViewIsometric, 0.5)
+	for i := 0; i < 29; i++ {
+		c.Animate(1. / 60)
+		c.Update()
+		if !c.Animating() {
+			t.Fatalf("transition ended after %d ticks", i+1)
+		}
+		// The camera swings around the pivot
+		if d, _ := c.E.Sub(pivot).Len(); math.Abs(d-c.Orbit.Distance) > 1e-6 {
+			t.Errorf("tick %d: distance to the pivot = %v", i, d)
+		}
+	}
+	// Allow for rounding in the accumulated time
+	for i := 0; i < 2; i++ {
+		c.Animate(1. / 60)
+		c.Update()
+	}
+	if c.Animating() {
+		t.Error("transition did not end")
+	}
+	if !near(c.At, c.ViewDirection(ViewIsometric)) {
+		t.Errorf("looking along %v, want %v", c.At, c.ViewDirection(ViewIsometric))
+	}
+	if !near(c.pivot(), pivot) {
+		t.Errorf("pivot moved to %v, want %v", c.pivot(), pivot)
+	}
+}
+
+func TestCamera_ShowViewExactTop(t *testing.T) {
+	c := NewCamera(vectozavr.BlenderConventions(), testViewport)
+	c.MaxPitch = math.Pi / 2
+	c.SetMode(ModeOrbit)
+	c.Orbit.Target = vectozavr.ZeroVec3()
+	c.ShowView(ViewTop, 0)
+	c.Update()
+	if !near(c.At, vectozavr.NewVec3(0, 0, -1)) {
+		t.Errorf("top view looks along %v", c.At)
+	}
+	if !near(c.E, vectozavr.NewVec3(0, 0, c.Orbit.Distance)) {
+		t.Errorf("top view camera at %v", c.E)
+	}
+}
@@ -0,0 +1,96 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Mouse-wheel zoom settings
+type Zoom struct {
+	Factor         float64 // magnification per wheel step
+	MinFov, MaxFov vectozavr.Degrees
+}
+
+func NewZoom() Zoom {
+	return Zoom{Factor: 1.1, MinFov: 5, MaxFov: 120}
+}
+
+// The distance multiplier for the wheel steps, positive steps zoom in
+func (z Zoom) scale(steps float64) float64 {
+	return math.Pow(z.Factor, -steps)
+}
+
+// Zooms in (positive steps) or out keeping the world point under the
+// cursor in place. A perspective camera moves towards the point; an
+// orthographic one shrinks its view volume and shifts sideways. In the
+// orbit mode the target moves along, so the orbit stays the same.
+func (c *Camera) ZoomAt(steps float64, point vectozavr.Vec3) {
+	k := c.Zoom.scale(steps)
+	if c.Orthographic {
+		c.OrthoHeight *= k
+		// Only the part across the view moves the point on the screen
+		shift := point.Sub(c.E)
+		shift = shift.Sub(c.At.Mul(shift.Dot(c.At))).Mul(1 - k)
+		c.E = c.E.Add(shift)
+		c.Orbit.Target = c.Orbit.Target.Add(shift)
+		return
+	}
+	d, err := point.Sub(c.E).Len()
+	if err != nil || d == 0 {
+		return
+	}
+	o := c.Orbit
+	clamp := func(d float64) float64 {
+		return math.Max(o.MinDistance, math.Min(o.MaxDistance, d))
+	}
+	k = clamp(d*k) / d
+	if c.Mode == ModeOrbit && o.Distance > 0 {
+		k = clamp(o.Distance*k) / o.Distance
+	}
+	// Scaling around the point keeps it on the same ray from the camera
+	c.E = point.Add(c.E.Sub(point).Mul(k))
+	c.Orbit.Target = point.Add(o.Target.Sub(point).Mul(k))
+	c.Orbit.Distance *= k
+}
+
+// Narrows (positive steps) or widens the field of view without moving
+// the camera; an orthographic camera scales its view volume instead
+func (c *Camera) ZoomFov(steps float64) {
+	k := c.Zoom.scale(steps)
+	if c.Orthographic {
+		c.OrthoHeight *= k
+		return
+	}
+	tan := (c.Fov / 2).Radians().Tan() * k
+	fov := vectozavr.Radians(2 * math.Atan(tan)).Degrees()
+	c.Fov = vectozavr.Degrees(math.Max(float64(c.Zoom.MinFov), math.Min(float64(c.Zoom.MaxFov), float64(fov))))
+}
+
+// The vertigo effect: moves the camera towards the target (positive steps)
+// or away from it while widening or narrowing the field of view, so that
+// things at the target's depth keep their size on the screen. The move
+// stops where the field of view reaches its limits. Orthographic cameras
+// have no perspective to change and stay as they are.
+func (c *Camera) DollyZoom(steps float64, target vectozavr.Vec3) {
+	if c.Orthographic {
+		return
+	}
+	d := target.Sub(c.E).Dot(c.At)
+	if d <= 0 {
+		return
+	}
+	// The width of the view at the target's depth, 2·d·tan(fov/2), stays the same
+	o := c.Orbit
+	d2 := math.Max(o.MinDistance, math.Min(o.MaxDistance, d*c.Zoom.scale(steps)))
+	tan := (c.Fov / 2).Radians().Tan()
+	lo, hi := (c.Zoom.MinFov / 2).Radians().Tan(), (c.Zoom.MaxFov / 2).Radians().Tan()
+	tan2 := math.Max(lo, math.Min(hi, tan*d/d2))
+	d2 = d * tan / tan2
+
+	c.Fov = vectozavr.Radians(2 * math.Atan(tan2)).Degrees()
+	c.E = c.E.Add(c.At.Mul(d - d2))
+	if c.Mode == ModeOrbit {
+		c.Orbit.Distance = c.Orbit.Target.Sub(c.E).Dot(c.At)
+	}
+}
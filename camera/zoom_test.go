@@ -0,0 +1,96 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestCamera_ZoomAt(t *testing.T) {
+	for _, ortho := range []bool{false, true} {
+		c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+		c.Far = 100
+		c.Orthographic = ortho
+		c.SetMode(ModeOrbit)
+		c.Update()
+		point := vectozavr.NewVec3(1, -0.5, 4)
+		before := c.Project(point)
+		height := c.OrthoHeight
+
+		c.ZoomAt(2, point)
+		c.Update()
+		after := c.Project(point)
+		if math.Abs(after.X-before.X) > 1e-6 || math.Abs(after.Y-before.Y) > 1e-6 {
+			t.Errorf("orthographic %v: point moved on the screen from %v to %v", ortho, before, after)
+		}
+		if d, _ := c.Orbit.Target.Sub(c.E).Len(); math.Abs(d-c.Orbit.Distance) > eps {
+			t.Errorf("orthographic %v: camera left the orbit, distance %v, want %v", ortho, d, c.Orbit.Distance)
+		}
+		if ortho {
+			if want := height / 1.21; math.Abs(c.OrthoHeight-want) > eps {
+				t.Errorf("ortho height = %v, want %v", c.OrthoHeight, want)
+			}
+		} else if d, _ := point.Sub(c.E).Len(); math.Abs(d-math.Sqrt(17.25)/1.21) > eps {
+			t.Errorf("distance to the point = %v, want %v", d, math.Sqrt(17.25)/1.21)
+		}
+	}
+}
+
+func TestCamera_ZoomAtClampsOrbit(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.SetMode(ModeOrbit)
+	c.Update()
+	// A point far behind the target lets the camera move well past it
+	point := c.E.Add(c.At.Mul(50))
+	c.ZoomAt(100, point)
+	if c.Orbit.Distance != c.Orbit.MinDistance {
+		t.Errorf("orbit distance = %v, want it clamped to %v", c.Orbit.Distance, c.Orbit.MinDistance)
+	}
+	c.ZoomAt(-1000, c.Orbit.Target)
+	if math.Abs(c.Orbit.Distance-c.Orbit.MaxDistance) > eps {
+		t.Errorf("orbit distance = %v, want it clamped to %v", c.Orbit.Distance, c.Orbit.MaxDistance)
+	}
+}
+
+func TestCamera_ZoomFov(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.ZoomFov(1)
+	want := 2 * math.Atan(math.Tan(math.Pi/6)/1.1) * 180 / math.Pi
+	if math.Abs(float64(c.Fov)-want) > 1e-9 {
+		t.Errorf("fov = %v, want %v", c.Fov, want)
+	}
+	c.ZoomFov(100)
+	if c.Fov != c.Zoom.MinFov {
+		t.Errorf("fov = %v, want the minimum %v", c.Fov, c.Zoom.MinFov)
+	}
+	c.ZoomFov(-100)
+	if c.Fov != c.Zoom.MaxFov {
+		t.Errorf("fov = %v, want the maximum %v", c.Fov, c.Zoom.MaxFov)
+	}
+}
+
+func TestCamera_DollyZoom(t *testing.T) {
+	c := NewCamera(vectozavr.DefaultConventions(), testViewport)
+	c.Far = 100
+	c.SetMode(ModeOrbit)
+	c.Update()
+	target := c.Orbit.Target
+	edge := target.Add(c.Up)
+	size := func() float64 { return c.Project(target).Y - c.Project(edge).Y }
+	want := size()
+
+	for _, steps := range []float64{3, -6, 50} {
+		c.DollyZoom(steps, target)
+		c.Update()
+		if got := size(); math.Abs(got-want) > 1e-6 {
+			t.Errorf("after %v steps the target is %v pixels tall, want %v", steps, got, want)
+		}
+		if d, _ := target.Sub(c.E).Len(); math.Abs(d-c.Orbit.Distance) > eps {
+			t.Errorf("orbit distance = %v, camera is %v away", c.Orbit.Distance, d)
+		}
+	}
+	if c.Fov != c.Zoom.MaxFov && math.Abs(float64(c.Fov-c.Zoom.MaxFov)) > 1e-9 {
+		t.Errorf("fov = %v, want it stopped at %v", c.Fov, c.Zoom.MaxFov)
+	}
+}
@@ -2,7 +2,10 @@ module github.com/rudolfkova/vectozavr
 
 go 1.23.1
 
-require github.com/hajimehoshi/ebiten/v2 v2.8.3
+require (
+	github.com/hajimehoshi/ebiten/v2 v2.8.3
+	gonum.org/v1/gonum v0.15.1
+)
 
 require (
 	github.com/ebitengine/gomobile v0.0.0-20240911145611-4856209ac325 // indirect
@@ -11,5 +14,4 @@ require (
 	github.com/jezek/xgb v1.1.1 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
-	gonum.org/v1/gonum v0.15.1 // indirect
 )
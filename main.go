@@ -1,10 +1,11 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
-	"math"
+	"os"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
@@ -16,96 +17,109 @@ import (
 )
 
 type Game struct {
-	scale float64
-	w     int
-	h     int
+	w int
+	h int
 
-	P vectozavr.Matrix
-	S vectozavr.Matrix
+	pos vectozavr.Vec4
 
-	invP vectozavr.Matrix
-	invS vectozavr.Matrix
-
-	angle, tilt, roll float64
-	pos               vectozavr.Vec4
-
-	cam    camera.Camera
+	conv vectozavr.Conventions
+	// Camera of the active pane
+	cam    *camera.Camera
 	visual bool
+	cursor vectozavr.Vec2
+
+	panes     []*pane
+	persp     *pane
+	active    *pane
+	maximized *pane
+
+	// Limits of the perspective camera, switched off and on with C
+	constraints *camera.Constraints
+
+	stereo     camera.Stereo
+	stereoMode stereoMode
+	eyeImages  [2]*ebiten.Image
+
+	path   *camera.Path
+	player *camera.Player
+	// Named camera states of the scene, stored next to it
+	scene    string
+	presets  camera.Presets
+	selected map[vectozavr.Vec3]bool
+
+	// Objects moving around the scene for the camera to follow; G switches between them
+	movers []*mover
+	time   float64
+	// Objects standing still
+	objects []*object.Object
+	// The ones among them the perspective camera bumps into: the
+	// primitives, but neither the gizmo nor loaded models
+	solids []*object.Object
+	// PLY models are loaded as point sets even if they have faces
+	pointClouds bool
 
 	pointXY []vectozavr.Vec3
 	pointXZ []vectozavr.Vec3
 	pointYZ []vectozavr.Vec3
 }
 
-func NewGame() *Game {
+func NewGame(conv vectozavr.Conventions) *Game {
 	g := &Game{
-		scale: 1.0,
-		w:     1000,
-		h:     700,
+		w:    1000,
+		h:    700,
+		conv: conv,
 	}
 	g.pos = vectozavr.NewVec4(0, 0, 4, 1)
-	g.P = vectozavr.Projection(60, float64(g.w)/float64(g.h), 1, 10)
-	g.invP, _ = g.P.Inverse()
-	g.S = vectozavr.ScreenSpace(float64(g.w), float64(g.h))
-	g.invS, _ = g.S.Inverse()
-	g.cam.At = vectozavr.NewVec3(0, 0, 1)
-	g.cam.Up = vectozavr.NewVec3(0, 1, 0)
-	g.cam.Left = vectozavr.NewVec3(1, 0, 0)
-	g.cam.InitCamera()
+	g.cam = camera.NewCamera(conv, camera.Viewport{Width: g.w, Height: g.h})
+	g.path = &camera.Path{}
+	g.player = camera.NewPlayer(g.path)
+	g.scene = "scene"
+	g.presets = camera.Presets{}
+	g.selected = map[vectozavr.Vec3]bool{}
+	g.stereo = camera.NewStereo()
+	g.movers = newMovers()
+	g.solids = newPrimitives(conv)
+	g.objects = append([]*object.Object{newGizmo(conv)}, g.solids...)
+	for _, m := range g.movers {
+		m.update(conv, 0)
+	}
+	g.newPanes()
+	g.constraints = g.newConstraints()
+	g.persp.cam.Constraints = g.constraints
 
 	return g
 }
 
-func (g *Game) ScreenToWorld(mousePos vectozavr.Vec2) (XY, XZ, YZ vectozavr.Vec3) {
-	tmp := vectozavr.NewVec4(((2.0*mousePos.X)/float64(g.w))-1.0, ((2.0*mousePos.Y)/float64(g.h))-1.0, -1, 1)
-	itmp := g.invP.Vec4Mul(tmp)
-	tmp = vectozavr.NewVec4(itmp.X, itmp.Y, -1, 0)
-	direction, _ := g.cam.InverseViewMatrix.Vec3Mul(tmp.ToVec3()).Normalize()
-	camPos := g.cam.InverseViewMatrix.Vec4Mul(vectozavr.NewVec4(0, 0, 0, 1)).ToVec3()
-
-	N := vectozavr.NewVec3(0, 0, 1)
-	t := -(camPos.Dot(N)) / direction.Dot(N)
-	resultXY := camPos.Add(direction.Mul(t))
-
-	N = vectozavr.NewVec3(0, 1, 0)
-	t = -(camPos.Dot(N)) / direction.Dot(N)
-	resultXZ := camPos.Add(direction.Mul(t))
-
-	N = vectozavr.NewVec3(1, 0, 0)
-	t = -(camPos.Dot(N)) / direction.Dot(N)
-	resultYZ := camPos.Add(direction.Mul(t))
-	return resultXY, resultXZ, resultYZ
+// Grid colours by the axis normal to the plane: YZ blue, XZ green, XY red
+var gridColors = [3]color.Color{
+	color.RGBA{0, 0, 255, 255},
+	color.RGBA{0, 255, 0, 255},
+	color.RGBA{255, 0, 0, 255},
 }
 
-func (g *Game) DrawGrid(screen *ebiten.Image, step float64, num float64) {
-	for i := -num; i <= num; i++ {
-		g.ProjLine(screen, vectozavr.NewVec3(i*step, -5, 0), vectozavr.NewVec3(i*step, 5, 0), vectozavr.NewVec3(0, 0, 0), color.RGBA{255, 0, 0, 255})
-		g.ProjLine(screen, vectozavr.NewVec3(-5, i*step, 0), vectozavr.NewVec3(5, i*step, 0), vectozavr.NewVec3(0, 0, 0), color.RGBA{255, 0, 0, 255})
-	}
+// Draws the grid on the coordinate plane normal to the axis
+func (g *Game) DrawGrid(screen *ebiten.Image, cam *camera.Camera, axis int, step float64, num float64) {
+	u, v := axisVector((axis+1)%3), axisVector((axis+2)%3)
+	zero := vectozavr.NewVec3(0, 0, 0)
 	for i := -num; i <= num; i++ {
-		g.ProjLine(screen, vectozavr.NewVec3(0, -5, i*step), vectozavr.NewVec3(0, 5, i*step), vectozavr.NewVec3(0, 0, 0), color.RGBA{0, 0, 255, 255})
-		g.ProjLine(screen, vectozavr.NewVec3(0, i*step, -5), vectozavr.NewVec3(0, i*step, 5), vectozavr.NewVec3(0, 0, 0), color.RGBA{0, 0, 255, 255})
-	}
-	for i := -num; i <= num; i++ {
-		g.ProjLine(screen, vectozavr.NewVec3(-5, 0, i*step), vectozavr.NewVec3(5, 0, i*step), vectozavr.NewVec3(0, 0, 0), color.RGBA{0, 255, 0, 255})
-		g.ProjLine(screen, vectozavr.NewVec3(i*step, 0, -5), vectozavr.NewVec3(i*step, 0, 5), vectozavr.NewVec3(0, 0, 0), color.RGBA{0, 255, 0, 255})
+		g.ProjLine(screen, cam, u.Mul(i*step).Add(v.Mul(-5)), u.Mul(i*step).Add(v.Mul(5)), zero, gridColors[axis])
+		g.ProjLine(screen, cam, v.Mul(i*step).Add(u.Mul(-5)), v.Mul(i*step).Add(u.Mul(5)), zero, gridColors[axis])
 	}
 }
 
-func (g *Game) ProjPoint(p vectozavr.Vec3) vectozavr.Vec4 {
-	var newPoint vectozavr.Vec4
-	//  = g.S.Vec4Mul(g.P.Vec4Mul(p.ToVec4().Add(g.pos)))
-	newPoint = p.ToVec4()
-	newPoint = g.cam.ViewMatrix.Vec4Mul(newPoint)
-	newPoint = g.P.Vec4Mul(newPoint)
-	newPoint, _ = newPoint.Div(newPoint.W)
-	newPoint = g.S.Vec4Mul(newPoint)
-
-	return newPoint
+func (g *Game) ProjPoint(cam *camera.Camera, p vectozavr.Vec3) vectozavr.Vec4 {
+	return cam.Project(p)
 }
 
-func (g *Game) DrawProjPoint(screen *ebiten.Image, p vectozavr.Vec3, color color.Color) {
-	pVec4 := g.ProjPoint(p)
+// Ring drawn around selected points
+var selectionColor = color.RGBA{255, 255, 255, 255}
+
+// Draws the point unless it is out of the camera's view
+func (g *Game) DrawProjPoint(screen *ebiten.Image, cam *camera.Camera, p vectozavr.Vec3, color color.Color) {
+	pVec4, ok := cam.WorldToScreen(p, cam.Viewport)
+	if !ok {
+		return
+	}
 	if !g.visual {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
 			"X:%.f\nY:%.f\nZ:%.f",
@@ -113,12 +127,15 @@ func (g *Game) DrawProjPoint(screen *ebiten.Image, p vectozavr.Vec3, color color
 		)
 	}
 	vector.DrawFilledCircle(screen, float32(pVec4.X), float32(pVec4.Y), 10, color, false)
+	if g.selected[p] {
+		vector.StrokeCircle(screen, float32(pVec4.X), float32(pVec4.Y), 13, 2, selectionColor, false)
+	}
 }
 
-func (g *Game) ProjLine(screen *ebiten.Image, p1, p2 vectozavr.Vec3, pos vectozavr.Vec3, color color.Color) {
+func (g *Game) ProjLine(screen *ebiten.Image, cam *camera.Camera, p1, p2 vectozavr.Vec3, pos vectozavr.Vec3, color color.Color) {
 	//  = g.S.Vec4Mul(g.P.Vec4Mul(p.ToVec4().Add(g.pos)))
-	p1Vec4 := g.ProjPoint(p1.Add(pos))
-	p2Vec4 := g.ProjPoint(p2.Add(pos))
+	p1Vec4 := g.ProjPoint(cam, p1.Add(pos))
+	p2Vec4 := g.ProjPoint(cam, p2.Add(pos))
 
 	if !g.visual {
 		ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
@@ -139,123 +156,436 @@ func (g *Game) ProjLine(screen *ebiten.Image, p1, p2 vectozavr.Vec3, pos vectoza
 func (g *Game) keys() {
 	if ebiten.IsKeyPressed(ebiten.KeyEnter) {
 		g.cam.E = vectozavr.NewVec3(0, 0, 0)
+		g.cam.FlyStop()
 
 	}
 	if inpututil.IsKeyJustPressed(ebiten.Key5) {
 		g.visual = !g.visual
 	}
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
-		g.cam.Move(vectozavr.NewVec3(0, 0.05, 0))
+	if inpututil.IsKeyJustPressed(ebiten.KeyO) && !g.active.fixed {
+		g.toggleOrbit()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyShift) {
-		g.cam.Move(vectozavr.NewVec3(0, -0.05, 0))
+	if inpututil.IsKeyJustPressed(ebiten.KeyTab) {
+		g.toggleMaximized()
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyA) {
-		dv, _ := g.cam.Left.Div(25.0)
-		dv.Y = 0
-		g.cam.Move(dv)
+	g.pathKeys()
+	if g.cam.Mode == camera.ModeFreeFly && !g.player.Playing && !g.cam.Animating() {
+		g.cam.FlyUpdate(g.flyInput(), g.dt())
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyD) {
+	g.mouse()
 
-		dv, _ := g.cam.Left.Div(-25.0)
-		dv.Y = 0
-		g.cam.Move(dv)
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		g.toggleSelection()
+	} else if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		g.addPoint(g.active, g.cursor)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyW) {
-		dv, _ := g.cam.At.Div(25.0)
-		dv.Y = 0
-		g.cam.Move(dv)
+	g.viewKeys()
+	g.fitKeys()
+	g.stereoKeys()
+	g.followKeys()
+	if err := g.exportKeys(); err != nil {
+		log.Println(err)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyS) {
-
-		dv, _ := g.cam.At.Div(-25.0)
-		dv.Y = 0
-		g.cam.Move(dv)
+	if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+		if g.persp.cam.Constraints == nil {
+			g.persp.cam.Constraints = g.constraints
+		} else {
+			g.persp.cam.Constraints = nil
+		}
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
-		g.cam.Rotate(0, 0.03)
-		g.angle += 0.03
+}
+
+// Radius of the spheres around clicked points the camera bumps into
+const pointRadius = 0.1
+
+// Keeps the perspective camera above the ground grid and within 50 units
+// of the origin
+func (g *Game) newConstraints() *camera.Constraints {
+	k := camera.NewConstraints()
+	bounds := vectozavr.NewAABB(vectozavr.NewVec3(-50, -50, -50), vectozavr.NewVec3(50, 50, 50))
+	k.Bounds = &bounds
+	k.Ground = camera.GroundPlane(vectozavr.NewPlane(g.conv.UpVector(), vectozavr.ZeroVec3()))
+	k.MinHeight = k.Radius
+	return k
+}
+
+// Clicked points as obstacles for the camera
+func (g *Game) pointColliders() []camera.Collider {
+	var cols []camera.Collider
+	for _, p := range g.allClicked() {
+		cols = append(cols, camera.SphereCollider{Center: p, Radius: pointRadius})
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) {
-		g.cam.Rotate(0, -0.03)
-		g.angle -= 0.03
+	return cols
+}
+
+// Share of the view left free around framed points
+const fitMargin = 0.1
+
+// Home frames all points, F the selection (or all points when nothing is
+// selected), Alt+F the followed object or the object under the cursor;
+// T and keypad 5 switch between perspective and orthographic
+func (g *Game) fitKeys() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		g.cam.FitPoints(g.allPoints(), fitMargin, viewTransition)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF) && ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		if o := g.focusObject(); o != nil {
+			if g.cam.Mode == camera.ModeFollow {
+				g.cam.FollowObject(nil)
+			}
+			g.cam.FitBox(o.Bounds(), fitMargin, viewTransition)
+		}
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		points := g.selectedPoints()
+		if len(points) == 0 {
+			points = g.allPoints()
+		}
+		g.cam.FitPoints(points, fitMargin, viewTransition)
+	}
+	if (inpututil.IsKeyJustPressed(ebiten.KeyT) || inpututil.IsKeyJustPressed(ebiten.KeyNumpad5)) && !g.active.fixed {
+		g.cam.SetOrthographic(!g.cam.Orthographic)
 	}
-	if g.angle > 2*math.Pi {
-		g.angle = 0.0
+}
+
+// All clicked points
+func (g *Game) allClicked() []vectozavr.Vec3 {
+	var points []vectozavr.Vec3
+	points = append(points, g.pointXY...)
+	points = append(points, g.pointXZ...)
+	points = append(points, g.pointYZ...)
+	return points
+}
+
+// All clicked points, or the corners of the grid when there are none
+func (g *Game) allPoints() []vectozavr.Vec3 {
+	points := g.allClicked()
+	if len(points) == 0 {
+		return vectozavr.NewAABB(vectozavr.NewVec3(-5, -5, -5), vectozavr.NewVec3(5, 5, 5)).Corners()
 	}
-	if g.angle < 0.0 {
-		g.angle = 2 * math.Pi
+	return points
+}
+
+func (g *Game) selectedPoints() []vectozavr.Vec3 {
+	var points []vectozavr.Vec3
+	for p := range g.selected {
+		points = append(points, p)
 	}
+	return points
+}
 
-	_, delta := ebiten.Wheel()
-	if delta > 0 {
-		g.scale *= 1.1
+// Selects or deselects the clicked point nearest to the cursor
+func (g *Game) toggleSelection() {
+	const pickRadius = 12
+	best, found := pickRadius*pickRadius+1.0, false
+	var picked vectozavr.Vec3
+	for _, points := range [][]vectozavr.Vec3{g.pointXY, g.pointXZ, g.pointYZ} {
+		for _, p := range points {
+			s, ok := g.cam.WorldToScreen(p, g.cam.Viewport)
+			if !ok {
+				continue
+			}
+			dx, dy := s.X-g.cursor.X, s.Y-g.cursor.Y
+			if d := dx*dx + dy*dy; d < best {
+				best, picked, found = d, p, true
+			}
+		}
+	}
+	if !found {
+		return
+	}
+	if g.selected[picked] {
+		delete(g.selected, picked)
+	} else {
+		g.selected[picked] = true
 	}
-	if delta < 0 {
-		g.scale *= 0.9
+}
+
+// Seconds a switch to a named view or a preset takes
+const viewTransition = 0.4
+
+// Standard views on the digit row and the keypad, Blender style:
+// 1 front, 3 right, 7 top and 0 isometric; with Ctrl the opposite view
+var viewKeys = []struct {
+	keys       []ebiten.Key
+	view, ctrl camera.View
+}{
+	{[]ebiten.Key{ebiten.Key1, ebiten.KeyNumpad1}, camera.ViewFront, camera.ViewBack},
+	{[]ebiten.Key{ebiten.Key3, ebiten.KeyNumpad3}, camera.ViewRight, camera.ViewLeft},
+	{[]ebiten.Key{ebiten.Key7, ebiten.KeyNumpad7}, camera.ViewTop, camera.ViewBottom},
+	{[]ebiten.Key{ebiten.Key0, ebiten.KeyNumpad0}, camera.ViewIsometric, camera.ViewIsometric},
+}
+
+// Preset slots: Ctrl+F1..F4 saves the camera into the scene's presets,
+// F1..F4 returns to it
+var presetKeys = []ebiten.Key{ebiten.KeyF1, ebiten.KeyF2, ebiten.KeyF3, ebiten.KeyF4}
+
+// While flying Ctrl speeds the camera up, so the Ctrl bindings are skipped
+func (g *Game) viewKeys() {
+	if g.active.fixed {
+		return
+	}
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl)
+	flying := g.flying()
+	for _, vk := range viewKeys {
+		for _, k := range vk.keys {
+			if !inpututil.IsKeyJustPressed(k) || (ctrl && flying) {
+				continue
+			}
+			if ctrl {
+				g.cam.ShowView(vk.ctrl, viewTransition)
+			} else {
+				g.cam.ShowView(vk.view, viewTransition)
+			}
+		}
+	}
+	for _, k := range presetKeys {
+		if !inpututil.IsKeyJustPressed(k) || (ctrl && flying) {
+			continue
+		}
+		name := k.String()
+		if ctrl {
+			g.presets[name] = g.cam.State()
+			if err := g.savePresets(); err != nil {
+				log.Println(err)
+			}
+			continue
+		}
+		if err := g.showView(name); err != nil {
+			log.Println(err)
+		}
 	}
+}
 
-	if ebiten.IsKeyPressed(ebiten.KeyUp) {
-		g.cam.Rotate(0.03, 0)
-		g.tilt += 0.03
+// Turns the camera to a preset or a standard view by name
+func (g *Game) showView(name string) error {
+	if s, ok := g.presets[name]; ok {
+		return g.cam.GoToState(s, viewTransition)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyDown) {
-		g.cam.Rotate(-0.03, 0)
-		g.tilt -= 0.03
+	v, err := camera.ParseView(name)
+	if err != nil {
+		return err
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyLeft) {
-		g.roll += 0.01
+	g.cam.ShowView(v, viewTransition)
+	return nil
+}
+
+// Puts the perspective camera at a preset or a standard view at once
+func (g *Game) startView(name string) error {
+	cam := g.persp.cam
+	if s, ok := g.presets[name]; ok {
+		return cam.SetState(s)
 	}
-	if ebiten.IsKeyPressed(ebiten.KeyRight) {
-		g.roll -= 0.01
+	v, err := camera.ParseView(name)
+	if err != nil {
+		return err
 	}
-	if g.tilt > 2*math.Pi {
-		g.tilt = 0
+	cam.ShowView(v, 0)
+	cam.Update()
+	return nil
+}
+
+// Free-fly input from WASD, Space/Shift, the arrow keys and Q/E;
+// Ctrl is the speed modifier
+func (g *Game) flyInput() camera.FlyInput {
+	axis := func(pos, neg ebiten.Key) float64 {
+		v := 0.0
+		if ebiten.IsKeyPressed(pos) {
+			v++
+		}
+		if ebiten.IsKeyPressed(neg) {
+			v--
+		}
+		return v
+	}
+	return camera.FlyInput{
+		Forward: axis(ebiten.KeyW, ebiten.KeyS),
+		Side:    axis(ebiten.KeyA, ebiten.KeyD),
+		Lift:    axis(ebiten.KeySpace, ebiten.KeyShift),
+		Pitch:   axis(ebiten.KeyUp, ebiten.KeyDown),
+		Yaw:     axis(ebiten.KeyLeft, ebiten.KeyRight),
+		Roll:    axis(ebiten.KeyQ, ebiten.KeyE),
+		Fast:    ebiten.IsKeyPressed(ebiten.KeyControl),
 	}
-	if g.tilt < 0.0 {
-		g.tilt = 2 * math.Pi
+}
+
+// Reports whether the active camera is flying by keys other than the
+// modifiers, which then mean fast (Ctrl) and down (Shift) instead of
+// their other bindings
+func (g *Game) flying() bool {
+	if g.cam.Mode != camera.ModeFreeFly {
+		return false
 	}
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		x, y := ebiten.CursorPosition()
-		mousePos := vectozavr.NewVec2(float64(x), float64(y))
-		XY, XZ, YZ := g.ScreenToWorld(mousePos)
-		g.pointXY = append(g.pointXY, XY)
-		g.pointXZ = append(g.pointXZ, XZ)
-		g.pointYZ = append(g.pointYZ, YZ)
+	in := g.flyInput()
+	return in.Forward != 0 || in.Side != 0 || in.Pitch != 0 || in.Yaw != 0 || in.Roll != 0 ||
+		ebiten.IsKeyPressed(ebiten.KeySpace)
+}
+
+// File the camera path is saved to and loaded from
+const pathFile = "camera_path.json"
 
+// Seconds between recorded keyframes
+const keyframeInterval = 2.0
+
+// Camera path keys: K records a keyframe, P plays or pauses, L toggles
+// looping, comma and period scrub, F5 saves and F9 loads the path.
+// Paths drive the perspective pane.
+func (g *Game) pathKeys() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyK) {
+		t := 0.0
+		if len(g.path.Keyframes) > 0 {
+			t = g.path.Duration() + keyframeInterval
+		}
+		g.path.Record(g.persp.cam, t)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyP) {
+		g.player.Toggle()
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.path.Loop = !g.path.Loop
 	}
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) {
-		//
+	step := g.dt()
+	if ebiten.IsKeyPressed(ebiten.KeyComma) {
+		g.player.Scrub(g.player.Time - step)
+		g.player.Apply(g.persp.cam)
 	}
-	if ebiten.IsKeyPressed(ebiten.Key1) {
-		g.tilt = math.Pi / 2
+	if ebiten.IsKeyPressed(ebiten.KeyPeriod) {
+		g.player.Scrub(g.player.Time + step)
+		g.player.Apply(g.persp.cam)
 	}
-	if ebiten.IsKeyPressed(ebiten.Key2) {
-		g.angle = math.Pi / 2
+	if inpututil.IsKeyJustPressed(ebiten.KeyF5) {
+		if err := g.savePath(); err != nil {
+			log.Println(err)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyF9) {
+		if err := g.loadPath(); err != nil {
+			log.Println(err)
+		}
 	}
 }
 
-func (g *Game) Update() error {
-	g.cam.Tilt = g.tilt
-	g.cam.Angle = g.angle
-	g.cam.ViewMat()
-	//-----------------------------------------------------------------
-	g.keys()
+func (g *Game) savePath() error {
+	f, err := os.Create(pathFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.path.Save(f)
+}
 
+func (g *Game) loadPath() error {
+	f, err := os.Open(pathFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p, err := camera.LoadPath(f)
+	if err != nil {
+		return err
+	}
+	g.path = p
+	g.player = camera.NewPlayer(p)
 	return nil
 }
 
-type axes struct {
-	v     vectozavr.Vec3
-	color color.Color
+// Mouse drags and the wheel: right-drag orbits, middle-drag pans, the wheel zooms
+func (g *Game) mouse() {
+	x, y := ebiten.CursorPosition()
+	cursor := vectozavr.NewVec2(float64(x), float64(y))
+	drag := cursor.Sub(g.cursor)
+	g.cursor = cursor
+
+	if _, delta := ebiten.Wheel(); delta != 0 {
+		g.zoom(delta)
+	}
+	if g.cam.Mode != camera.ModeOrbit {
+		return
+	}
+
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight) && !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && !g.active.fixed {
+		g.cam.OrbitRotate(drag.X, drag.Y)
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) && !inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		g.cam.OrbitPan(drag.X, drag.Y)
+	}
 }
 
-type Cube struct {
-	A, B, C, D, E, F, G, H vectozavr.Vec4
+// Wheel zoom towards the point under the cursor; with Alt the wheel
+// changes the field of view and with Shift, unless it is flying the camera
+// down, it makes a dolly zoom that keeps the point's size. A following
+// camera only changes its field of view.
+func (g *Game) zoom(steps float64) {
+	switch {
+	case ebiten.IsKeyPressed(ebiten.KeyAlt) || g.cam.Mode == camera.ModeFollow:
+		g.cam.ZoomFov(steps)
+	case ebiten.IsKeyPressed(ebiten.KeyShift) && !g.flying():
+		g.cam.DollyZoom(steps, g.cursorPoint())
+	default:
+		g.cam.ZoomAt(steps, g.cursorPoint())
+	}
+	g.cam.FlyStop()
 }
-type Place struct {
-	A, B, C, D vectozavr.Vec3
-	color      color.Color
+
+// The world point under the cursor: where the ray crosses the grid of the
+// active pane, or the point at the orbit distance along the ray
+func (g *Game) cursorPoint() vectozavr.Vec3 {
+	ray := g.cam.RayFromScreen(g.cursor.X, g.cursor.Y, g.cam.Viewport)
+	if hit, ok := g.active.plane().Intersect(ray); ok {
+		if d, _ := hit.Sub(ray.Origin).Len(); d < g.cam.Far {
+			return hit
+		}
+	}
+	return ray.At(g.cam.Orbit.Distance)
+}
+
+// Switches between the free-fly and the orbit camera
+func (g *Game) toggleOrbit() {
+	if g.cam.Mode == camera.ModeOrbit {
+		g.cam.SetMode(camera.ModeFreeFly)
+		return
+	}
+	g.cam.SetMode(camera.ModeOrbit)
+}
+
+// Makes the pane under the cursor active, unless a mouse button is held
+// for a drag that started elsewhere
+func (g *Game) activatePane() {
+	for _, b := range []ebiten.MouseButton{ebiten.MouseButtonLeft, ebiten.MouseButtonRight, ebiten.MouseButtonMiddle} {
+		if ebiten.IsMouseButtonPressed(b) && !inpututil.IsMouseButtonJustPressed(b) {
+			return
+		}
+	}
+	if p := g.paneAt(ebiten.CursorPosition()); p != nil {
+		g.active = p
+		g.cam = p.cam
+	}
+}
+
+// Seconds per Update tick
+func (g *Game) dt() float64 {
+	return 1 / float64(ebiten.TPS())
+}
+
+func (g *Game) Update() error {
+	g.activatePane()
+	g.updateMovers()
+	g.constraints.Colliders = append(g.pointColliders(), g.moverColliders()...)
+	g.constraints.Colliders = append(g.constraints.Colliders, g.solidColliders()...)
+	if g.player.Playing {
+		g.player.Update(g.dt())
+		g.player.Apply(g.persp.cam)
+	}
+	if g.persp.cam.Mode == camera.ModeFollow && !g.player.Playing {
+		g.persp.cam.FollowUpdate(g.dt())
+	}
+	for _, p := range g.panes {
+		p.cam.Animate(g.dt())
+		p.cam.Update()
+	}
+	//-----------------------------------------------------------------
+	g.keys()
+
+	return nil
 }
 
 func (g *Game) Draw(screen *ebiten.Image) {
@@ -274,21 +604,23 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// g.ProjLine(screen, vectozavr.NewVec3(0, 1, 0), vectozavr.NewVec3(0, 1, 1), vectozavr.NewVec3(0, 0, 4), color.RGBA{255, 0, 0, 255})
 	// g.ProjLine(screen, vectozavr.NewVec3(1, -1, 0), vectozavr.NewVec3(1, -1, 1), vectozavr.NewVec3(0, 0, 4), color.RGBA{255, 0, 0, 255})
 
-	g.DrawGrid(screen, 0.5, 10)
-
-	for _, p := range g.pointXY {
-		g.DrawProjPoint(screen, p, color.RGBA{255, 0, 0, 255})
-	}
-	for _, p := range g.pointXZ {
-		g.DrawProjPoint(screen, p, color.RGBA{0, 255, 0, 255})
-	}
-	for _, p := range g.pointYZ {
-		g.DrawProjPoint(screen, p, color.RGBA{0, 0, 255, 255})
+	for _, p := range g.panes {
+		g.drawPane(screen, p)
 	}
 
+	x, y := ebiten.CursorPosition()
+	cursor := g.cam.Conventions.ScreenPoint(vectozavr.NewVec2(float64(x), float64(y)), float64(g.h))
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
+		"Fov: %.2f, Pitch: %.2f, Yaw: %.2f, Roll: %.2f, Cursor: %.f, %.f",
+		g.cam.Fov, g.cam.Pitch, g.cam.Yaw, g.cam.Roll, cursor.X, cursor.Y), 0, 0,
+	)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
-		"g.Scale: %.2f, Tilt: %.2f, Angle: %.2f",
-		g.scale, g.tilt, g.angle), 0, 0,
+		"Path: %d keys, %.2f/%.2fs, playing: %v, loop: %v",
+		len(g.path.Keyframes), g.player.Time, g.path.Duration(), g.player.Playing, g.path.Loop), 0, 16,
+	)
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
+		"Stereo: %v, separation: %.2f, convergence: %.2f",
+		g.stereoMode, g.stereo.EyeSeparation, g.stereo.Convergence), 0, 32,
 	)
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
 		"g.cam.E(cam pos): %.2f \n g.cam.ViewMatrix: %.2f",
@@ -297,22 +629,122 @@ func (g *Game) Draw(screen *ebiten.Image) {
 
 	ebitenutil.DebugPrintAt(screen, fmt.Sprintf(
 		"A: %.f\nB:%.f",
-		g.pos, g.P), 0, g.w/2,
+		g.pos, g.cam.Projection), 0, g.w/2,
 	)
 
 }
 
+// Draws a cube turned like the scene seen by the camera, with the
+// world axes and the names of the faces turned to the camera
+func (g *Game) drawViewCube(screen *ebiten.Image, cam *camera.Camera, center vectozavr.Vec2, size float64) {
+	// Orthographic projection of a world direction through the camera's rotation
+	proj := func(v vectozavr.Vec3) vectozavr.Vec2 {
+		d := g.conv.ScreenSpace(2, 2).Vec4Mul(vectozavr.NewVec4(v.Dot(cam.Left), v.Dot(cam.Up), 0, 0))
+		return center.Add(vectozavr.NewVec2(d.X, d.Y).Mul(size))
+	}
+	line := func(a, b vectozavr.Vec3, clr color.Color) {
+		pa, pb := proj(a), proj(b)
+		vector.StrokeLine(screen, float32(pa.X), float32(pa.Y), float32(pb.X), float32(pb.Y), 1, clr, false)
+	}
+
+	// Corner i has the coordinates ±1 picked by its three lowest bits
+	corner := func(i int) vectozavr.Vec3 {
+		sign := func(bit int) float64 { return float64(i>>bit&1*2 - 1) }
+		return vectozavr.NewVec3(sign(0), sign(1), sign(2))
+	}
+	// Edges join the corners that differ in one bit
+	for i := 0; i < 8; i++ {
+		for bit := 1; bit < 8; bit <<= 1 {
+			if i&bit == 0 {
+				line(corner(i), corner(i|bit), color.Gray{128})
+			}
+		}
+	}
+	zero := vectozavr.ZeroVec3()
+	line(zero, vectozavr.NewVec3(1.5, 0, 0), color.RGBA{255, 0, 0, 255})
+	line(zero, vectozavr.NewVec3(0, 1.5, 0), color.RGBA{0, 255, 0, 255})
+	line(zero, vectozavr.NewVec3(0, 0, 1.5), color.RGBA{0, 0, 255, 255})
+
+	// A face is named after the view that looks straight at it
+	for _, v := range camera.Views[:camera.ViewIsometric] {
+		normal := cam.ViewDirection(v).Mul(-1)
+		if normal.Dot(cam.At) >= 0 {
+			continue
+		}
+		p := proj(normal)
+		name := v.String()
+		ebitenutil.DebugPrintAt(screen, name, int(p.X)-3*len(name), int(p.Y)-8)
+	}
+}
+
 func (g *Game) Layout(w, h int) (int, int) {
+	if w != g.w || h != g.h {
+		g.w, g.h = w, h
+		g.layoutPanes()
+	}
 	return w, h
 }
 
+// Coordinate conventions selectable with the -conventions flag
+var conventions = map[string]vectozavr.Conventions{
+	"default": vectozavr.DefaultConventions(),
+	"blender": vectozavr.BlenderConventions(),
+	"unity":   vectozavr.UnityConventions(),
+	"opengl":  vectozavr.OpenGLConventions(),
+}
+
 func main() {
+	convName := flag.String("conventions", "default", "coordinate conventions: default, blender, unity or opengl")
+	tps := flag.Int("tps", ebiten.DefaultTPS, "updates per second")
+	view := flag.String("view", "", "initial view: a preset of the scene or front, back, top, bottom, left, right, isometric")
+	scene := flag.String("scene", "scene", "scene name; camera presets are kept in <scene>.cameras.json")
+	restore := flag.Bool("restore", true, "restore the cameras of the last session")
+	points := flag.Bool("points", false, "show the vertices of PLY models as points")
+	var models []string
+	flag.Func("model", "model file to show: .obj, .stl or .ply; may be repeated", func(path string) error {
+		models = append(models, path)
+		return nil
+	})
+	flag.Parse()
+	conv, ok := conventions[*convName]
+	if !ok {
+		log.Fatalf("unknown conventions %q", *convName)
+	}
+	if *tps < 1 {
+		log.Fatalf("-tps must be at least 1, got %d", *tps)
+	}
+
 	var _ vectozavr.Vec3
 	var _ object.Object
-	g := NewGame()
+	g := NewGame(conv)
+	g.scene = *scene
+	g.pointClouds = *points
+	for _, path := range models {
+		if err := g.loadModel(path); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := g.loadPresets(); err != nil {
+		log.Println(err)
+	}
+	if *restore {
+		if err := g.loadSession(); err != nil {
+			log.Println(err)
+		}
+	}
+	ebiten.SetTPS(*tps)
+	if *view != "" {
+		if err := g.startView(*view); err != nil {
+			log.Fatal(err)
+		}
+	}
 	ebiten.SetWindowSize(g.w, g.h)
 	ebiten.SetWindowTitle("Coords")
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	if err := ebiten.RunGame(g); err != nil {
 		log.Fatal(err)
 	}
+	if err := g.saveSession(); err != nil {
+		log.Println(err)
+	}
 }
@@ -0,0 +1,142 @@
+package mesh
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Assembles a mesh vertex by vertex. Triangles with coinciding corners and
+// repeated or zero-length edges are dropped, so the seams and poles of
+// the generated surfaces need no special cases.
+type builder struct {
+	m     *Mesh
+	edges map[[2]posKey]bool
+}
+
+// A position rounded so that the same point computed in two ways matches
+type posKey [3]float64
+
+func keyOf(p vectozavr.Vec3) posKey {
+	const grid = 1e9
+	r := func(x float64) float64 { return math.Round(x*grid) / grid }
+	return posKey{r(p.X), r(p.Y), r(p.Z)}
+}
+
+func newBuilder() *builder {
+	return &builder{m: &Mesh{}, edges: map[[2]posKey]bool{}}
+}
+
+// Adds a vertex and returns its index
+func (b *builder) vertex(p, n vectozavr.Vec3, uv vectozavr.Vec2) int {
+	b.m.Positions = append(b.m.Positions, p)
+	b.m.Normals = append(b.m.Normals, n)
+	b.m.UVs = append(b.m.UVs, uv)
+	return len(b.m.Positions) - 1
+}
+
+func (b *builder) key(i int) posKey {
+	return keyOf(b.m.Positions[i])
+}
+
+// Adds a triangle, counter-clockwise seen from the side its normals point to
+func (b *builder) triangle(i, j, k int) {
+	ki, kj, kk := b.key(i), b.key(j), b.key(k)
+	if ki == kj || kj == kk || kk == ki {
+		return
+	}
+	b.m.Triangles = append(b.m.Triangles, [3]int{i, j, k})
+}
+
+// Adds a wireframe edge unless one between the same points exists
+func (b *builder) edge(i, j int) {
+	ki, kj := b.key(i), b.key(j)
+	if ki == kj || b.edges[[2]posKey{ki, kj}] || b.edges[[2]posKey{kj, ki}] {
+		return
+	}
+	b.edges[[2]posKey{ki, kj}] = true
+	b.m.Edges = append(b.m.Edges, [2]int{i, j})
+}
+
+// Adds the quad i, j, k, l as two triangles and its four sides as edges
+func (b *builder) quad(i, j, k, l int) {
+	b.triangle(i, j, k)
+	b.triangle(i, k, l)
+	b.edge(i, j)
+	b.edge(j, k)
+	b.edge(k, l)
+	b.edge(l, i)
+}
+
+// Adds a grid of nu×nv quads spanning u and v from the corner; u×v must
+// point to the side the face looks at
+func (b *builder) grid(corner, u, v, normal vectozavr.Vec3, nu, nv int) {
+	first := len(b.m.Positions)
+	for j := 0; j <= nv; j++ {
+		for i := 0; i <= nu; i++ {
+			s, t := float64(i)/float64(nu), float64(j)/float64(nv)
+			b.vertex(corner.Add(u.Mul(s)).Add(v.Mul(t)), normal, vectozavr.NewVec2(s, t))
+		}
+	}
+	at := func(i, j int) int { return first + j*(nu+1) + i }
+	for j := 0; j < nv; j++ {
+		for i := 0; i < nu; i++ {
+			b.quad(at(i, j), at(i+1, j), at(i+1, j+1), at(i, j+1))
+		}
+	}
+}
+
+// A point of a profile revolved around the Y axis: the distance from the
+// axis, the height and the normal in the same (r, y) plane
+type profilePoint struct {
+	r, y   float64
+	nr, ny float64
+}
+
+// Revolves the profile around the Y axis in the given number of segments.
+// Walking the profile from the bottom up keeps the triangles facing the
+// normals when those point away from the axis. V runs along the profile
+// by its length and U around the axis.
+func (b *builder) lathe(profile []profilePoint, segments int) {
+	length := make([]float64, len(profile))
+	for k := 1; k < len(profile); k++ {
+		length[k] = length[k-1] + math.Hypot(profile[k].r-profile[k-1].r, profile[k].y-profile[k-1].y)
+	}
+	total := length[len(length)-1]
+	if total == 0 {
+		total = 1
+	}
+
+	first := len(b.m.Positions)
+	for k, p := range profile {
+		for i := 0; i <= segments; i++ {
+			// The last column closes the seam exactly
+			phi := 2 * math.Pi * float64(i%segments) / float64(segments)
+			dir := vectozavr.NewVec3(math.Cos(phi), 0, -math.Sin(phi))
+			pos := dir.Mul(p.r).Add(vectozavr.NewVec3(0, p.y, 0))
+			n, err := dir.Mul(p.nr).Add(vectozavr.NewVec3(0, p.ny, 0)).Normalize()
+			if err != nil {
+				n = vectozavr.NewVec3(0, math.Copysign(1, p.ny), 0)
+			}
+			b.vertex(pos, n, vectozavr.NewVec2(float64(i)/float64(segments), length[k]/total))
+		}
+	}
+	at := func(i, k int) int { return first + k*(segments+1) + i }
+	for k := 0; k+1 < len(profile); k++ {
+		for i := 0; i < segments; i++ {
+			b.quad(at(i, k), at(i+1, k), at(i+1, k+1), at(i, k+1))
+		}
+	}
+}
+
+// Points of an arc of radius r around (cr, cy) from angle a0 to a1 in n
+// steps, with the normals pointing away from the centre
+func arc(cr, cy, r, a0, a1 float64, n int) []profilePoint {
+	points := make([]profilePoint, n+1)
+	for i := range points {
+		a := a0 + (a1-a0)*float64(i)/float64(n)
+		c, s := math.Cos(a), math.Sin(a)
+		points[i] = profilePoint{r: cr + r*c, y: cy + r*s, nr: c, ny: s}
+	}
+	return points
+}
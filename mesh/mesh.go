@@ -0,0 +1,167 @@
+package mesh
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Geometry in the coordinates of its object: vertices with optional
+// per-vertex attributes, triangles and edges indexing the vertices
+type Mesh struct {
+	Positions []vectozavr.Vec3
+	// Optional, either empty or one per position
+	Normals []vectozavr.Vec3
+	Colors  []color.RGBA
+	UVs     []vectozavr.Vec2
+
+	Triangles [][3]int
+	// Lines drawn in the wireframe; without them the triangle sides are drawn
+	Edges [][2]int
+}
+
+// Creates a mesh of the triangles over the positions
+func NewMesh(positions []vectozavr.Vec3, triangles [][3]int) *Mesh {
+	return &Mesh{Positions: positions, Triangles: triangles}
+}
+
+// Checks that the attributes match the positions and the indices are in range
+func (m *Mesh) Validate() error {
+	n := len(m.Positions)
+	for name, l := range map[string]int{"normals": len(m.Normals), "colors": len(m.Colors), "UVs": len(m.UVs)} {
+		if l != 0 && l != n {
+			return fmt.Errorf("mesh has %d %s for %d positions", l, name, n)
+		}
+	}
+	for i, t := range m.Triangles {
+		for _, v := range t {
+			if v < 0 || v >= n {
+				return fmt.Errorf("triangle %d refers to vertex %d of %d", i, v, n)
+			}
+		}
+	}
+	for i, e := range m.Edges {
+		for _, v := range e {
+			if v < 0 || v >= n {
+				return fmt.Errorf("edge %d refers to vertex %d of %d", i, v, n)
+			}
+		}
+	}
+	return nil
+}
+
+// The smallest box around the positions
+func (m *Mesh) Bounds() vectozavr.AABB {
+	return vectozavr.NewAABB(m.Positions...)
+}
+
+// The lines of the wireframe: the edges, or the sides of the triangles
+// with each side shared by two triangles listed once
+func (m *Mesh) WireEdges() [][2]int {
+	if len(m.Edges) > 0 {
+		return m.Edges
+	}
+	seen := map[[2]int]bool{}
+	var edges [][2]int
+	for _, t := range m.Triangles {
+		for i := 0; i < 3; i++ {
+			a, b := t[i], t[(i+1)%3]
+			key := [2]int{min(a, b), max(a, b)}
+			if !seen[key] {
+				seen[key] = true
+				edges = append(edges, [2]int{a, b})
+			}
+		}
+	}
+	return edges
+}
+
+// Paints every vertex in the colour
+func (m *Mesh) SetColor(c color.RGBA) {
+	m.Colors = make([]color.RGBA, len(m.Positions))
+	for i := range m.Colors {
+		m.Colors[i] = c
+	}
+}
+
+// Transforms the positions by the matrix and turns the normals with it.
+// The normals stay correct for rotations, translations and uniform scales.
+func (m *Mesh) Transform(t vectozavr.Matrix) {
+	for i, p := range m.Positions {
+		m.Positions[i] = t.Vec4Mul(p.ToVec4()).ToVec3()
+	}
+	for i, n := range m.Normals {
+		if r, err := t.Vec3Mul(n).Normalize(); err == nil {
+			m.Normals[i] = r
+		}
+	}
+}
+
+// Adds the vertices, triangles and edges of another mesh. An attribute
+// is kept only when both meshes have it, or the mesh was empty.
+func (m *Mesh) Append(o *Mesh) {
+	n := len(m.Positions)
+	keep := func(mine, theirs int) bool { return n == 0 || mine > 0 && theirs > 0 }
+	if keep(len(m.Normals), len(o.Normals)) {
+		m.Normals = append(m.Normals, o.Normals...)
+	} else {
+		m.Normals = nil
+	}
+	if keep(len(m.Colors), len(o.Colors)) {
+		m.Colors = append(m.Colors, o.Colors...)
+	} else {
+		m.Colors = nil
+	}
+	if keep(len(m.UVs), len(o.UVs)) {
+		m.UVs = append(m.UVs, o.UVs...)
+	} else {
+		m.UVs = nil
+	}
+	// Triangle sides stand in for the edges of a mesh without them
+	if len(m.Edges) == 0 && n > 0 {
+		m.Edges = m.WireEdges()
+	}
+	for _, e := range o.WireEdges() {
+		m.Edges = append(m.Edges, [2]int{e[0] + n, e[1] + n})
+	}
+	for _, t := range o.Triangles {
+		m.Triangles = append(m.Triangles, [3]int{t[0] + n, t[1] + n, t[2] + n})
+	}
+	m.Positions = append(m.Positions, o.Positions...)
+}
+
+// Sets the normals to the average of the normals of the triangles around
+// each vertex, weighted by their areas. Vertices outside any triangle
+// get the Y axis.
+func (m *Mesh) ComputeNormals() {
+	sums := make([]vectozavr.Vec3, len(m.Positions))
+	for _, t := range m.Triangles {
+		a, b, c := m.Positions[t[0]], m.Positions[t[1]], m.Positions[t[2]]
+		// The cross product is twice the area long
+		n := b.Sub(a).Cross(c.Sub(a))
+		for _, v := range t {
+			sums[v] = sums[v].Add(n)
+		}
+	}
+	m.Normals = make([]vectozavr.Vec3, len(m.Positions))
+	for i, s := range sums {
+		n, err := s.Normalize()
+		if err != nil {
+			n = vectozavr.NewVec3(0, 1, 0)
+		}
+		m.Normals[i] = n
+	}
+}
+
+// Returns a copy sharing nothing with the mesh
+func (m *Mesh) Clone() *Mesh {
+	return &Mesh{
+		Positions: append([]vectozavr.Vec3(nil), m.Positions...),
+		Normals:   append([]vectozavr.Vec3(nil), m.Normals...),
+		Colors:    append([]color.RGBA(nil), m.Colors...),
+		UVs:       append([]vectozavr.Vec2(nil), m.UVs...),
+		Triangles: append([][3]int(nil), m.Triangles...),
+		Edges:     append([][2]int(nil), m.Edges...),
+	}
+}
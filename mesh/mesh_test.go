@@ -0,0 +1,74 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Two triangles making the unit square in the XY plane
+func testQuad() *Mesh {
+	return NewMesh([]vectozavr.Vec3{
+		vectozavr.NewVec3(0, 0, 0),
+		vectozavr.NewVec3(1, 0, 0),
+		vectozavr.NewVec3(1, 1, 0),
+		vectozavr.NewVec3(0, 1, 0),
+	}, [][3]int{{0, 1, 2}, {0, 2, 3}})
+}
+
+func TestMesh_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(m *Mesh)
+		wantErr bool
+	}{
+		{name: "testValid", change: func(m *Mesh) {}},
+		{name: "testNormals", change: func(m *Mesh) { m.Normals = make([]vectozavr.Vec3, 4) }},
+		{name: "testShortUVs", change: func(m *Mesh) { m.UVs = make([]vectozavr.Vec2, 3) }, wantErr: true},
+		{name: "testTriangleIndex", change: func(m *Mesh) { m.Triangles[1][2] = 4 }, wantErr: true},
+		{name: "testEdgeIndex", change: func(m *Mesh) { m.Edges = [][2]int{{-1, 0}} }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testQuad()
+			tt.change(m)
+			if err := m.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMesh_WireEdges(t *testing.T) {
+	m := testQuad()
+	// The diagonal is shared by both triangles
+	if got := len(m.WireEdges()); got != 5 {
+		t.Errorf("%d wire edges, want 5", got)
+	}
+	m.Edges = [][2]int{{0, 1}, {1, 2}, {2, 3}, {3, 0}}
+	if got := len(m.WireEdges()); got != 4 {
+		t.Errorf("%d wire edges, want the 4 given", got)
+	}
+	if b := m.Bounds(); b.Min != vectozavr.ZeroVec3() || b.Max != vectozavr.NewVec3(1, 1, 0) {
+		t.Errorf("bounds = %v", b)
+	}
+}
+
+func TestMesh_ComputeNormals(t *testing.T) {
+	m := Cube(2)
+	want := append([]vectozavr.Vec3(nil), m.Normals...)
+	// Every vertex of the box belongs to a single face
+	m.Normals = nil
+	m.ComputeNormals()
+	for i, n := range m.Normals {
+		if d, _ := n.Sub(want[i]).Len(); d > 1e-9 {
+			t.Errorf("normal %d = %v, want %v", i, n, want[i])
+		}
+	}
+
+	c := m.Clone()
+	c.Positions[0] = vectozavr.NewVec3(9, 9, 9)
+	if m.Positions[0] == c.Positions[0] {
+		t.Errorf("clone shares positions with the mesh")
+	}
+}
@@ -0,0 +1,251 @@
+package mesh
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// The generated meshes are centred on the origin with Y up. Their
+// triangles turn counter-clockwise seen from outside, the normals point
+// outwards and the UVs cover [0, 1]².
+
+var (
+	axisX = vectozavr.NewVec3(1, 0, 0)
+	axisY = vectozavr.NewVec3(0, 1, 0)
+	axisZ = vectozavr.NewVec3(0, 0, 1)
+)
+
+// A box with the given edge lengths, each face mapped to the whole UV square
+func Box(size vectozavr.Vec3) *Mesh {
+	b := newBuilder()
+	faces := []struct{ n, u, v vectozavr.Vec3 }{
+		{axisX, axisZ.Mul(-1), axisY},
+		{axisX.Mul(-1), axisZ, axisY},
+		{axisY, axisX, axisZ.Mul(-1)},
+		{axisY.Mul(-1), axisX, axisZ},
+		{axisZ, axisX, axisY},
+		{axisZ.Mul(-1), axisX.Mul(-1), axisY},
+	}
+	scale := func(v vectozavr.Vec3) vectozavr.Vec3 {
+		return vectozavr.NewVec3(v.X*size.X, v.Y*size.Y, v.Z*size.Z)
+	}
+	for _, f := range faces {
+		u, v := scale(f.u), scale(f.v)
+		corner := scale(f.n.Mul(0.5)).Sub(u.Mul(0.5)).Sub(v.Mul(0.5))
+		b.grid(corner, u, v, f.n, 1, 1)
+	}
+	return b.m
+}
+
+// A cube with edges of the given length
+func Cube(size float64) *Mesh {
+	return Box(vectozavr.NewVec3(size, size, size))
+}
+
+// A horizontal plane facing up, divided into a grid of quads; U runs
+// along X and V along -Z
+func Plane(width, depth float64, segmentsX, segmentsZ int) *Mesh {
+	b := newBuilder()
+	u, v := axisX.Mul(width), axisZ.Mul(-depth)
+	b.grid(u.Add(v).Mul(-0.5), u, v, axisY, max(1, segmentsX), max(1, segmentsZ))
+	return b.m
+}
+
+// A single rectangle in the XY plane facing +Z
+func Quad(width, height float64) *Mesh {
+	b := newBuilder()
+	u, v := axisX.Mul(width), axisY.Mul(height)
+	b.grid(u.Add(v).Mul(-0.5), u, v, axisZ, 1, 1)
+	return b.m
+}
+
+// A sphere of meridians and parallels: segments around the Y axis and
+// rings from pole to pole
+func UVSphere(radius float64, segments, rings int) *Mesh {
+	b := newBuilder()
+	b.lathe(arc(0, 0, radius, -math.Pi/2, math.Pi/2, max(2, rings)), max(3, segments))
+	return b.m
+}
+
+// A sphere made by splitting every triangle of an icosahedron into four
+// level times. The UVs are the longitude and latitude of the vertices.
+// Triangles crossing the -X meridian use copies of their vertices with
+// u past 1, so the texture repeats across the seam, and every triangle
+// has its own copy of a pole vertex in the middle of its longitudes.
+func Icosphere(radius float64, level int) *Mesh {
+	t := (1 + math.Sqrt(5)) / 2
+	points := []vectozavr.Vec3{
+		{X: -1, Y: t}, {X: 1, Y: t}, {X: -1, Y: -t}, {X: 1, Y: -t},
+		{Y: -1, Z: t}, {Y: 1, Z: t}, {Y: -1, Z: -t}, {Y: 1, Z: -t},
+		{X: t, Z: -1}, {X: t, Z: 1}, {X: -t, Z: -1}, {X: -t, Z: 1},
+	}
+	faces := [][3]int{
+		{0, 11, 5}, {0, 5, 1}, {0, 1, 7}, {0, 7, 10}, {0, 10, 11},
+		{1, 5, 9}, {5, 11, 4}, {11, 10, 2}, {10, 7, 6}, {7, 1, 8},
+		{3, 9, 4}, {3, 4, 2}, {3, 2, 6}, {3, 6, 8}, {3, 8, 9},
+		{4, 9, 5}, {2, 4, 11}, {6, 2, 10}, {8, 6, 7}, {9, 8, 1},
+	}
+	for i := range points {
+		points[i], _ = points[i].Normalize()
+	}
+
+	for ; level > 0; level-- {
+		mid := map[[2]int]int{}
+		middle := func(a, b int) int {
+			key := [2]int{min(a, b), max(a, b)}
+			if i, ok := mid[key]; ok {
+				return i
+			}
+			p, _ := points[a].Add(points[b]).Normalize()
+			points = append(points, p)
+			mid[key] = len(points) - 1
+			return mid[key]
+		}
+		split := make([][3]int, 0, 4*len(faces))
+		for _, f := range faces {
+			a, b, c := middle(f[0], f[1]), middle(f[1], f[2]), middle(f[2], f[0])
+			split = append(split, [3]int{f[0], a, c}, [3]int{f[1], b, a}, [3]int{f[2], c, b}, [3]int{a, b, c})
+		}
+		faces = split
+	}
+
+	m := &Mesh{}
+	for _, n := range points {
+		m.Positions = append(m.Positions, n.Mul(radius))
+		m.Normals = append(m.Normals, n)
+		u := 0.5 + math.Atan2(-n.Z, n.X)/(2*math.Pi)
+		if u >= 1 {
+			u = 0
+		}
+		v := 0.5 + math.Asin(math.Max(-1, math.Min(1, n.Y)))/math.Pi
+		m.UVs = append(m.UVs, vectozavr.NewVec2(u, v))
+	}
+	// Adds a copy of vertex i at u
+	copyAt := func(i int, u float64) int {
+		m.Positions = append(m.Positions, m.Positions[i])
+		m.Normals = append(m.Normals, m.Normals[i])
+		m.UVs = append(m.UVs, vectozavr.NewVec2(u, m.UVs[i].Y))
+		return len(m.Positions) - 1
+	}
+	wrapped := map[int]int{}
+	for _, f := range faces {
+		pole := -1
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for k, i := range f {
+			if math.Abs(points[i].Y) > 1-1e-9 {
+				pole = k
+				continue
+			}
+			lo, hi = math.Min(lo, m.UVs[i].X), math.Max(hi, m.UVs[i].X)
+		}
+		if hi-lo > 0.5 {
+			for k, i := range f {
+				if k == pole || m.UVs[i].X >= 0.5 {
+					continue
+				}
+				if _, ok := wrapped[i]; !ok {
+					wrapped[i] = copyAt(i, m.UVs[i].X+1)
+				}
+				f[k] = wrapped[i]
+			}
+		}
+		if pole >= 0 {
+			a, b := f[(pole+1)%3], f[(pole+2)%3]
+			f[pole] = copyAt(f[pole], (m.UVs[a].X+m.UVs[b].X)/2)
+		}
+		m.Triangles = append(m.Triangles, f)
+	}
+	return m
+}
+
+// A closed cylinder along the Y axis, split into segments around it and
+// into rings along it
+func Cylinder(radius, height float64, segments, rings int) *Mesh {
+	h := height / 2
+	profile := []profilePoint{{0, -h, 0, -1}, {radius, -h, 0, -1}}
+	rings = max(1, rings)
+	for i := 0; i <= rings; i++ {
+		profile = append(profile, profilePoint{radius, -h + height*float64(i)/float64(rings), 1, 0})
+	}
+	profile = append(profile, profilePoint{radius, h, 0, 1}, profilePoint{0, h, 0, 1})
+	b := newBuilder()
+	b.lathe(profile, max(3, segments))
+	return b.m
+}
+
+// A closed cone along the Y axis with the tip at the top
+func Cone(radius, height float64, segments int) *Mesh {
+	h := height / 2
+	// The slanted side faces outwards and up
+	nr, ny := height, radius
+	profile := []profilePoint{
+		{0, -h, 0, -1}, {radius, -h, 0, -1},
+		{radius, -h, nr, ny}, {0, h, nr, ny},
+	}
+	b := newBuilder()
+	b.lathe(profile, max(3, segments))
+	return b.m
+}
+
+// A ring around the Y axis: radius to the centre of the tube, tube the
+// radius of the tube, segments around the axis and sides around the tube
+func Torus(radius, tube float64, segments, sides int) *Mesh {
+	b := newBuilder()
+	b.lathe(arc(radius, 0, tube, 0, 2*math.Pi, max(3, sides)), max(3, segments))
+	return b.m
+}
+
+// A cylinder of the given height between two hemispheres, each of rings
+// parallels; the whole capsule is height+2·radius tall
+func Capsule(radius, height float64, segments, rings int) *Mesh {
+	h := height / 2
+	rings = max(1, rings)
+	profile := arc(0, -h, radius, -math.Pi/2, 0, rings)
+	profile = append(profile, arc(0, h, radius, 0, math.Pi/2, rings)...)
+	b := newBuilder()
+	b.lathe(profile, max(3, segments))
+	return b.m
+}
+
+// An arrow from the origin up the Y axis: a shaft with a conical head
+// of the given length and radius at the end
+func Arrow(length, shaftRadius, headLength, headRadius float64, segments int) *Mesh {
+	shaft := math.Max(0, length-headLength)
+	nr, ny := headLength, headRadius
+	profile := []profilePoint{
+		{0, 0, 0, -1}, {shaftRadius, 0, 0, -1},
+		{shaftRadius, 0, 1, 0}, {shaftRadius, shaft, 1, 0},
+		{shaftRadius, shaft, 0, -1}, {headRadius, shaft, 0, -1},
+		{headRadius, shaft, nr, ny}, {0, length, nr, ny},
+	}
+	b := newBuilder()
+	b.lathe(profile, max(3, segments))
+	return b.m
+}
+
+// Colours of the axes gizmo: X red, Y green, Z blue
+var AxisColors = [3]color.RGBA{
+	{255, 0, 0, 255},
+	{0, 255, 0, 255},
+	{0, 0, 255, 255},
+}
+
+// Three arrows of the given length along the X, Y and Z axes in the axis colours
+func Axes(length float64) *Mesh {
+	m := &Mesh{}
+	// Rotations taking the Y axis to each of the axes
+	turns := []vectozavr.Matrix{
+		vectozavr.NewMatrixVec3(axisY.Mul(-1), axisX, axisZ),
+		vectozavr.Identity(),
+		vectozavr.NewMatrixVec3(axisX, axisZ, axisY.Mul(-1)),
+	}
+	for i, turn := range turns {
+		a := Arrow(length, length*0.02, length*0.2, length*0.06, 12)
+		a.Transform(turn)
+		a.SetColor(AxisColors[i])
+		m.Append(a)
+	}
+	return m
+}
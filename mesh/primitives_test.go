@@ -0,0 +1,139 @@
+package mesh
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestPrimitives(t *testing.T) {
+	tests := []struct {
+		name   string
+		m      *Mesh
+		bounds vectozavr.Vec3 // the box is symmetric unless low is set
+		low    *vectozavr.Vec3
+		closed bool
+		// UVs past 1 repeat the texture across a seam
+		wrapsU bool
+	}{
+		{name: "testBox", m: Box(vectozavr.NewVec3(1, 2, 3)), bounds: vectozavr.NewVec3(0.5, 1, 1.5), closed: true},
+		{name: "testPlane", m: Plane(4, 2, 4, 2), bounds: vectozavr.NewVec3(2, 0, 1)},
+		{name: "testQuad", m: Quad(4, 2), bounds: vectozavr.NewVec3(2, 1, 0)},
+		{name: "testUVSphere", m: UVSphere(2, 16, 8), bounds: vectozavr.NewVec3(2, 2, 2), closed: true},
+		{name: "testIcosphere", m: Icosphere(2, 2), bounds: vectozavr.NewVec3(2, 2, 2), closed: true, wrapsU: true},
+		{name: "testCylinder", m: Cylinder(1, 4, 12, 3), bounds: vectozavr.NewVec3(1, 2, 1), closed: true},
+		{name: "testCone", m: Cone(1, 4, 12), bounds: vectozavr.NewVec3(1, 2, 1), closed: true},
+		{name: "testTorus", m: Torus(2, 0.5, 16, 8), bounds: vectozavr.NewVec3(2.5, 0.5, 2.5), closed: true},
+		{name: "testCapsule", m: Capsule(1, 2, 12, 4), bounds: vectozavr.NewVec3(1, 2, 1), closed: true},
+		{name: "testArrow", m: Arrow(2, 0.1, 0.5, 0.3, 8), bounds: vectozavr.NewVec3(0.3, 2, 0.3), low: &vectozavr.Vec3{X: -0.3, Z: -0.3}, closed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := tt.m
+			if err := m.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if len(m.Normals) != len(m.Positions) || len(m.UVs) != len(m.Positions) {
+				t.Fatalf("%d normals and %d UVs for %d positions", len(m.Normals), len(m.UVs), len(m.Positions))
+			}
+
+			low := tt.bounds.Mul(-1)
+			if tt.low != nil {
+				low = *tt.low
+			}
+			b := m.Bounds()
+			if !nearVec3(b.Min, low, 1e-9) || !nearVec3(b.Max, tt.bounds, 1e-9) {
+				t.Errorf("bounds %v, want %v..%v", b, low, tt.bounds)
+			}
+
+			for i, n := range m.Normals {
+				if l, _ := n.Len(); math.Abs(l-1) > 1e-9 {
+					t.Fatalf("normal %d is %v long", i, l)
+				}
+				maxU := 1.0
+				if tt.wrapsU {
+					maxU = 2
+				}
+				if uv := m.UVs[i]; uv.X < -1e-9 || uv.X > maxU+1e-9 || uv.Y < -1e-9 || uv.Y > 1+1e-9 {
+					t.Fatalf("UV %d = %v is out of the unit square", i, uv)
+				}
+			}
+
+			// No triangle stretches back across the seam
+			if tt.wrapsU {
+				for i, tri := range m.Triangles {
+					lo, hi := math.Inf(1), math.Inf(-1)
+					for _, k := range tri {
+						lo, hi = math.Min(lo, m.UVs[k].X), math.Max(hi, m.UVs[k].X)
+					}
+					if hi-lo > 0.5 {
+						t.Fatalf("triangle %d %v spans u %v..%v", i, tri, lo, hi)
+					}
+				}
+			}
+
+			// Each triangle turns counter-clockwise around its normals
+			for i, tri := range m.Triangles {
+				a, b, c := m.Positions[tri[0]], m.Positions[tri[1]], m.Positions[tri[2]]
+				face := b.Sub(a).Cross(c.Sub(a))
+				n := m.Normals[tri[0]].Add(m.Normals[tri[1]]).Add(m.Normals[tri[2]])
+				if face.Dot(n) <= 0 {
+					t.Fatalf("triangle %d %v faces away from its normals", i, tri)
+				}
+			}
+
+			// In a closed surface every side is shared by exactly two triangles
+			if tt.closed {
+				sides := map[[2]posKey]int{}
+				for _, tri := range m.Triangles {
+					for k := 0; k < 3; k++ {
+						p, q := keyOf(m.Positions[tri[k]]), keyOf(m.Positions[tri[(k+1)%3]])
+						sides[[2]posKey{p, q}]++
+						if sides[[2]posKey{q, p}] > 1 || sides[[2]posKey{p, q}] > 1 {
+							t.Fatalf("side %v..%v is used twice in the same direction", p, q)
+						}
+					}
+				}
+				for s := range sides {
+					if sides[[2]posKey{s[1], s[0]}] != 1 {
+						t.Fatalf("side %v..%v has no opposite", s[0], s[1])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBox_Edges(t *testing.T) {
+	m := Cube(2)
+	if len(m.Triangles) != 12 || len(m.WireEdges()) != 12 {
+		t.Errorf("%d triangles and %d edges, want 12 and 12", len(m.Triangles), len(m.WireEdges()))
+	}
+}
+
+func TestAxes(t *testing.T) {
+	m := Axes(1)
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	// The tip of each arrow is in the colour of its axis
+	for i, axis := range []vectozavr.Vec3{axisX, axisY, axisZ} {
+		found := false
+		for j, p := range m.Positions {
+			if nearVec3(p, axis, 1e-9) {
+				found = true
+				if m.Colors[j] != AxisColors[i] {
+					t.Errorf("tip at %v is %v, want %v", p, m.Colors[j], AxisColors[i])
+				}
+			}
+		}
+		if !found {
+			t.Errorf("no arrow tip at %v", axis)
+		}
+	}
+}
+
+func nearVec3(a, b vectozavr.Vec3, eps float64) bool {
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps && math.Abs(a.Z-b.Z) < eps
+}
@@ -0,0 +1,498 @@
+// Package meshio reads and writes meshes in common model file formats
+package meshio
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A material of an MTL file; only the diffuse colour is used
+type Material struct {
+	Name    string
+	Diffuse color.RGBA
+}
+
+// Colour of faces without a material
+var DefaultColor = color.RGBA{200, 200, 200, 255}
+
+// Opens the files an OBJ file refers to, such as its material libraries
+type Opener func(name string) (io.ReadCloser, error)
+
+// Reads an OBJ file into one object per group or object statement, each
+// with its own mesh. Polygons are split into triangles by ear clipping and
+// keep their outlines as the mesh edges; line statements become edges. Faces get the
+// diffuse colour of their material from the libraries open returns;
+// with a nil open materials are ignored.
+func ReadOBJ(r io.Reader, open Opener) ([]*object.Object, error) {
+	p := &objParser{materials: map[string]Material{}, open: open}
+	p.part = p.newPart("")
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		p.line++
+		if err := p.parseLine(sc.Text()); err != nil {
+			return nil, fmt.Errorf("obj line %d: %v", p.line, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read obj: %v", err)
+	}
+	return p.objects(), nil
+}
+
+// Reads an OBJ file with its material libraries next to it
+func LoadOBJ(path string) ([]*object.Object, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dir := filepath.Dir(path)
+	return ReadOBJ(f, func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, name))
+	})
+}
+
+// Reads the materials of an MTL file by name
+func ReadMTL(r io.Reader) (map[string]Material, error) {
+	materials := map[string]Material{}
+	cur := ""
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		f := strings.Fields(stripComment(sc.Text()))
+		if len(f) == 0 {
+			continue
+		}
+		switch f[0] {
+		case "newmtl":
+			if len(f) < 2 {
+				return nil, fmt.Errorf("mtl line %d: material without a name", line)
+			}
+			cur = strings.Join(f[1:], " ")
+			materials[cur] = Material{Name: cur, Diffuse: DefaultColor}
+		case "Kd":
+			m, ok := materials[cur]
+			if !ok {
+				return nil, fmt.Errorf("mtl line %d: colour outside a material", line)
+			}
+			v, err := parseFloats(f[1:], 3, 3)
+			if err != nil {
+				return nil, fmt.Errorf("mtl line %d: %v", line, err)
+			}
+			m.Diffuse = color.RGBA{unit8(v[0]), unit8(v[1]), unit8(v[2]), 255}
+			materials[cur] = m
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("cannot read mtl: %v", err)
+	}
+	return materials, nil
+}
+
+// A group of the file being read
+type objPart struct {
+	name  string
+	mesh  *mesh.Mesh
+	index map[objVertex]int
+	// Whether any vertex has a normal, a UV or a colour, and whether any lacks one
+	normals, uvs, colors bool
+	noNormals, noUVs     bool
+}
+
+// A face corner: indices of the position, UV and normal (-1 for none)
+// and the material colour
+type objVertex struct {
+	v, vt, vn int
+	color     color.RGBA
+	colored   bool
+}
+
+type objParser struct {
+	line int
+	open Opener
+
+	positions []vectozavr.Vec3
+	colors    []color.RGBA // vertex colours, if the file has them
+	uvs       []vectozavr.Vec2
+	normals   []vectozavr.Vec3
+
+	materials map[string]Material
+	material  *Material
+
+	parts []*objPart
+	part  *objPart
+}
+
+func (p *objParser) newPart(name string) *objPart {
+	part := &objPart{name: name, mesh: &mesh.Mesh{}, index: map[objVertex]int{}}
+	p.parts = append(p.parts, part)
+	return part
+}
+
+func (p *objParser) parseLine(line string) error {
+	f := strings.Fields(stripComment(line))
+	if len(f) == 0 {
+		return nil
+	}
+	args := f[1:]
+	switch f[0] {
+	case "v":
+		v, err := parseFloats(args, 3, 7)
+		if err != nil {
+			return err
+		}
+		if len(v) == 5 {
+			return fmt.Errorf("5 numbers in a vertex")
+		}
+		p.positions = append(p.positions, vectozavr.NewVec3(v[0], v[1], v[2]))
+		// x y z r g b is a common extension; x y z w has a weight instead
+		if len(v) >= 6 {
+			for len(p.colors) < len(p.positions)-1 {
+				p.colors = append(p.colors, DefaultColor)
+			}
+			p.colors = append(p.colors, color.RGBA{unit8(v[3]), unit8(v[4]), unit8(v[5]), 255})
+		}
+	case "vt":
+		v, err := parseFloats(args, 1, 3)
+		if err != nil {
+			return err
+		}
+		v = append(v, 0)
+		p.uvs = append(p.uvs, vectozavr.NewVec2(v[0], v[1]))
+	case "vn":
+		v, err := parseFloats(args, 3, 3)
+		if err != nil {
+			return err
+		}
+		n, err := vectozavr.NewVec3(v[0], v[1], v[2]).Normalize()
+		if err != nil {
+			return fmt.Errorf("zero normal")
+		}
+		p.normals = append(p.normals, n)
+	case "f":
+		if len(args) < 3 {
+			return fmt.Errorf("face with %d vertices", len(args))
+		}
+		return p.face(args)
+	case "l":
+		if len(args) < 2 {
+			return fmt.Errorf("line with %d vertices", len(args))
+		}
+		return p.polyline(args)
+	case "o", "g":
+		name := strings.Join(args, " ")
+		if len(p.part.mesh.Positions) == 0 {
+			p.part.name = name
+		} else {
+			p.part = p.newPart(name)
+		}
+	case "usemtl":
+		name := strings.Join(args, " ")
+		if m, ok := p.materials[name]; ok {
+			p.material = &m
+		} else {
+			p.material = nil
+		}
+	case "mtllib":
+		return p.loadMaterials(args)
+	}
+	return nil
+}
+
+func (p *objParser) loadMaterials(files []string) error {
+	if p.open == nil {
+		return nil
+	}
+	for _, name := range files {
+		rc, err := p.open(name)
+		if err != nil {
+			return fmt.Errorf("cannot open material library: %v", err)
+		}
+		materials, err := ReadMTL(rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+		for k, m := range materials {
+			p.materials[k] = m
+		}
+	}
+	return nil
+}
+
+// Adds the polygon as triangles, see triangulate, and its outline as edges
+func (p *objParser) face(args []string) error {
+	corners := make([]int, len(args))
+	for i, a := range args {
+		v, err := p.corner(a)
+		if err != nil {
+			return err
+		}
+		corners[i] = v
+	}
+	m := p.part.mesh
+	m.Triangles = append(m.Triangles, triangulate(m.Positions, corners)...)
+	for i := range corners {
+		m.Edges = append(m.Edges, [2]int{corners[i], corners[(i+1)%len(corners)]})
+	}
+	return nil
+}
+
+func (p *objParser) polyline(args []string) error {
+	prev := -1
+	for _, a := range args {
+		v, err := p.corner(a)
+		if err != nil {
+			return err
+		}
+		if prev >= 0 {
+			p.part.mesh.Edges = append(p.part.mesh.Edges, [2]int{prev, v})
+		}
+		prev = v
+	}
+	return nil
+}
+
+// Returns the mesh vertex of a v, v/vt, v//vn or v/vt/vn reference,
+// adding it on first use
+func (p *objParser) corner(ref string) (int, error) {
+	refs := strings.Split(ref, "/")
+	if len(refs) > 3 {
+		return 0, fmt.Errorf("bad vertex reference %q", ref)
+	}
+	key := objVertex{v: -1, vt: -1, vn: -1}
+	var err error
+	if key.v, err = resolve(refs[0], len(p.positions)); err != nil {
+		return 0, err
+	}
+	if len(refs) > 1 && refs[1] != "" {
+		if key.vt, err = resolve(refs[1], len(p.uvs)); err != nil {
+			return 0, err
+		}
+	}
+	if len(refs) > 2 && refs[2] != "" {
+		if key.vn, err = resolve(refs[2], len(p.normals)); err != nil {
+			return 0, err
+		}
+	}
+	switch {
+	case p.material != nil:
+		key.color, key.colored = p.material.Diffuse, true
+	case key.v < len(p.colors):
+		key.color, key.colored = p.colors[key.v], true
+	}
+
+	part := p.part
+	if i, ok := part.index[key]; ok {
+		return i, nil
+	}
+	m := part.mesh
+	i := len(m.Positions)
+	part.index[key] = i
+	m.Positions = append(m.Positions, p.positions[key.v])
+	m.Normals = append(m.Normals, vectozavr.ZeroVec3())
+	m.UVs = append(m.UVs, vectozavr.Vec2{})
+	m.Colors = append(m.Colors, DefaultColor)
+	if key.vn >= 0 {
+		m.Normals[i], part.normals = p.normals[key.vn], true
+	} else {
+		part.noNormals = true
+	}
+	if key.vt >= 0 {
+		m.UVs[i], part.uvs = p.uvs[key.vt], true
+	} else {
+		part.noUVs = true
+	}
+	if key.colored {
+		m.Colors[i], part.colors = key.color, true
+	}
+	return i, nil
+}
+
+// Resolves a one-based index, or a negative one counting back from the last
+// element read, into a zero-based one
+func resolve(s string, n int) (int, error) {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad index %q", s)
+	}
+	if i < 0 {
+		i += n
+	} else {
+		i--
+	}
+	if i < 0 || i >= n {
+		return 0, fmt.Errorf("index %s out of range 1..%d", s, n)
+	}
+	return i, nil
+}
+
+// The parts with any geometry as objects. An attribute only some of the
+// vertices of a part have is dropped. A file of bare vertices becomes
+// a single object of points.
+func (p *objParser) objects() []*object.Object {
+	var objects []*object.Object
+	if len(p.parts) == 1 && len(p.part.mesh.Positions) == 0 && len(p.positions) > 0 {
+		m := p.part.mesh
+		m.Positions = p.positions
+		if len(p.colors) > 0 {
+			for len(p.colors) < len(p.positions) {
+				p.colors = append(p.colors, DefaultColor)
+			}
+			m.Colors = p.colors
+		}
+		o := object.NewObject(vectozavr.Identity())
+		o.Name, o.Mesh = p.part.name, m
+		return []*object.Object{o}
+	}
+	for _, part := range p.parts {
+		m := part.mesh
+		if len(m.Positions) == 0 {
+			continue
+		}
+		if !part.normals || part.noNormals {
+			m.Normals = nil
+		}
+		if !part.uvs || part.noUVs {
+			m.UVs = nil
+		}
+		if !part.colors {
+			m.Colors = nil
+		}
+		o := object.NewObject(vectozavr.Identity())
+		o.Name, o.Mesh = part.name, m
+		objects = append(objects, o)
+	}
+	return objects
+}
+
+// Writes the meshes of the objects, one OBJ object each, in world
+// coordinates taken to the coordinates of the file by toFile: the inverse
+// of the frame the file is loaded with, or Identity. Vertex colours are
+// written after the positions, as many tools read them. Meshes without
+// triangles are written as their edges.
+func WriteOBJ(w io.Writer, objects []*object.Object, toFile vectozavr.Matrix) error {
+	bw := bufio.NewWriter(w)
+	var nv, nvt, nvn int
+	for i, o := range objects {
+		m := o.Mesh
+		if m == nil {
+			continue
+		}
+		name := o.Name
+		if name == "" {
+			name = fmt.Sprintf("object%d", i+1)
+		}
+		fmt.Fprintf(bw, "o %s\n", name)
+		world := toFile.MatMul(o.World())
+		for j, v := range m.Positions {
+			v = world.Vec4Mul(v.ToVec4()).ToVec3()
+			if len(m.Colors) == len(m.Positions) {
+				c := m.Colors[j]
+				fmt.Fprintf(bw, "v %s %s %s %s %s %s\n", ftoa(v.X), ftoa(v.Y), ftoa(v.Z),
+					ftoa(float64(c.R)/255), ftoa(float64(c.G)/255), ftoa(float64(c.B)/255))
+			} else {
+				fmt.Fprintf(bw, "v %s %s %s\n", ftoa(v.X), ftoa(v.Y), ftoa(v.Z))
+			}
+		}
+		uvs := len(m.UVs) == len(m.Positions)
+		if uvs {
+			for _, uv := range m.UVs {
+				fmt.Fprintf(bw, "vt %s %s\n", ftoa(uv.X), ftoa(uv.Y))
+			}
+		}
+		normals := len(m.Normals) == len(m.Positions)
+		if normals {
+			for _, n := range m.Normals {
+				if t, err := world.Vec3Mul(n).Normalize(); err == nil {
+					n = t
+				}
+				fmt.Fprintf(bw, "vn %s %s %s\n", ftoa(n.X), ftoa(n.Y), ftoa(n.Z))
+			}
+		}
+		ref := func(j int) string {
+			switch {
+			case uvs && normals:
+				return fmt.Sprintf("%d/%d/%d", nv+j+1, nvt+j+1, nvn+j+1)
+			case uvs:
+				return fmt.Sprintf("%d/%d", nv+j+1, nvt+j+1)
+			case normals:
+				return fmt.Sprintf("%d//%d", nv+j+1, nvn+j+1)
+			}
+			return strconv.Itoa(nv + j + 1)
+		}
+		for _, t := range m.Triangles {
+			fmt.Fprintf(bw, "f %s %s %s\n", ref(t[0]), ref(t[1]), ref(t[2]))
+		}
+		if len(m.Triangles) == 0 {
+			for _, e := range m.Edges {
+				fmt.Fprintf(bw, "l %d %d\n", nv+e[0]+1, nv+e[1]+1)
+			}
+		}
+		nv += len(m.Positions)
+		if uvs {
+			nvt += len(m.UVs)
+		}
+		if normals {
+			nvn += len(m.Normals)
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("cannot write obj: %v", err)
+	}
+	return nil
+}
+
+// Writes the points as the vertices of one object joined by a polyline
+// in their order
+func WritePointsOBJ(w io.Writer, name string, points []vectozavr.Vec3) error {
+	m := &mesh.Mesh{Positions: points}
+	for i := 1; i < len(points); i++ {
+		m.Edges = append(m.Edges, [2]int{i - 1, i})
+	}
+	o := object.NewObject(vectozavr.Identity())
+	o.Name, o.Mesh = name, m
+	return WriteOBJ(w, []*object.Object{o}, vectozavr.Identity())
+}
+
+func stripComment(line string) string {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// Parses between lo and hi numbers
+func parseFloats(args []string, lo, hi int) ([]float64, error) {
+	if len(args) < lo || len(args) > hi {
+		return nil, fmt.Errorf("%d numbers, want %d to %d", len(args), lo, hi)
+	}
+	v := make([]float64, len(args))
+	for i, a := range args {
+		f, err := strconv.ParseFloat(a, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %q", a)
+		}
+		v[i] = f
+	}
+	return v, nil
+}
+
+// Converts a colour component from [0, 1] to a byte
+func unit8(x float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, x)) * 255))
+}
+
+func ftoa(x float64) string {
+	return strconv.FormatFloat(x, 'g', -1, 64)
+}
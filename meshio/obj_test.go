@@ -0,0 +1,234 @@
+package meshio
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+const testOBJ = `# a quad and a triangle in two groups
+mtllib colors.mtl
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 1 1
+vt 0 1
+vn 0 0 1
+g quad
+usemtl red
+f 1/1/1 2/2/1 3/3/1 4/4/1
+g tri
+usemtl missing
+v 0 0 1
+f -1 -4 -3
+l 1 2
+`
+
+const testMTL = `newmtl red
+Kd 1 0 0
+`
+
+func testOpener(name string) (io.ReadCloser, error) {
+	if name != "colors.mtl" {
+		return nil, fmt.Errorf("no file %q", name)
+	}
+	return io.NopCloser(strings.NewReader(testMTL)), nil
+}
+
+func TestReadOBJ(t *testing.T) {
+	objects, err := ReadOBJ(strings.NewReader(testOBJ), testOpener)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("%d objects, want 2", len(objects))
+	}
+
+	quad := objects[0].Mesh
+	if objects[0].Name != "quad" || len(quad.Triangles) != 2 || len(quad.Edges) != 4 {
+		t.Errorf("quad %q has %d triangles and %d edges, want 2 and 4", objects[0].Name, len(quad.Triangles), len(quad.Edges))
+	}
+	if len(quad.Normals) != 4 || len(quad.UVs) != 4 || quad.UVs[2] != vectozavr.NewVec2(1, 1) {
+		t.Errorf("quad normals %v, UVs %v", quad.Normals, quad.UVs)
+	}
+	if len(quad.Colors) != 4 || quad.Colors[0] != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("quad colours %v, want red", quad.Colors)
+	}
+
+	// Negative indices count back from the last vertex read
+	tri := objects[1].Mesh
+	if objects[1].Name != "tri" || len(tri.Triangles) != 1 {
+		t.Fatalf("triangle %q has %d triangles", objects[1].Name, len(tri.Triangles))
+	}
+	want := []vectozavr.Vec3{vectozavr.NewVec3(0, 0, 1), vectozavr.NewVec3(1, 0, 0), vectozavr.NewVec3(1, 1, 0)}
+	for i, v := range tri.Triangles[0] {
+		if tri.Positions[v] != want[i] {
+			t.Errorf("corner %d at %v, want %v", i, tri.Positions[v], want[i])
+		}
+	}
+	if tri.Normals != nil || tri.UVs != nil || tri.Colors != nil {
+		t.Errorf("triangle has attributes none of its vertices have")
+	}
+	if len(tri.Edges) != 4 {
+		t.Errorf("%d triangle edges, want 3 sides and a line", len(tri.Edges))
+	}
+}
+
+func TestReadOBJ_Errors(t *testing.T) {
+	for _, src := range []string{
+		"v 0 0 0\nv 1 0 0\nf 1 2 3\n",
+		"v 0 0\n",
+		"v 0 0 0\nf 1 1\n",
+		"v 0 0 0\nvn 0 0 0\n",
+		"v a 0 0\n",
+		"v 0 0 0\nf 1/1/1/1 1 1\n",
+	} {
+		if _, err := ReadOBJ(strings.NewReader(src), nil); err == nil {
+			t.Errorf("no error reading %q", src)
+		}
+	}
+	if _, err := ReadOBJ(strings.NewReader("mtllib other.mtl\n"), testOpener); err == nil {
+		t.Errorf("no error for a missing material library")
+	}
+}
+
+func TestWriteOBJ(t *testing.T) {
+	box := object.NewObject(vectozavr.Identity())
+	box.Name, box.Mesh = "box", mesh.Box(vectozavr.NewVec3(1, 2, 3))
+	box.Translate(vectozavr.NewVec3(5, 0, 0))
+	ball := object.NewObject(vectozavr.Identity())
+	ball.Name, ball.Mesh = "ball", mesh.Icosphere(1, 1)
+	ball.Mesh.SetColor(color.RGBA{0, 255, 0, 255})
+
+	var buf bytes.Buffer
+	if err := WriteOBJ(&buf, []*object.Object{box, ball}, vectozavr.Identity()); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := ReadOBJ(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Fatalf("%d objects read back, want 2", len(objects))
+	}
+	for i, o := range []*object.Object{box, ball} {
+		got := objects[i]
+		if got.Name != o.Name || len(got.Mesh.Triangles) != len(o.Mesh.Triangles) {
+			t.Errorf("%q read back as %q with %d triangles", o.Name, got.Name, len(got.Mesh.Triangles))
+		}
+		// Written in world coordinates
+		if b, want := got.Mesh.Bounds(), o.Bounds(); !vecNear(b.Min, want.Min) || !vecNear(b.Max, want.Max) {
+			t.Errorf("%q bounds %v, want %v", o.Name, b, want)
+		}
+	}
+	if c := objects[1].Mesh.Colors; len(c) == 0 || c[0] != (color.RGBA{0, 255, 0, 255}) {
+		t.Errorf("ball colours read back as %v", c)
+	}
+}
+
+func TestWriteOBJFrame(t *testing.T) {
+	// Z up in the file, Y up in the world
+	frame := vectozavr.NewMatrixVec3(vectozavr.NewVec3(1, 0, 0), vectozavr.NewVec3(0, 0, -1), vectozavr.NewVec3(0, 1, 0))
+	toFile, err := frame.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	box := object.NewObject(vectozavr.Identity())
+	box.Mesh = mesh.Box(vectozavr.NewVec3(1, 2, 3))
+	box.Translate(vectozavr.NewVec3(5, 1, 0))
+
+	var buf bytes.Buffer
+	if err := WriteOBJ(&buf, []*object.Object{box}, toFile); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := ReadOBJ(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Loading with the frame puts the box back where it was
+	got := objects[0]
+	got.SetTransform(frame)
+	if b, want := got.Bounds(), box.Bounds(); !vecNear(b.Min, want.Min) || !vecNear(b.Max, want.Max) {
+		t.Errorf("bounds after a round trip %v, want %v", b, want)
+	}
+}
+
+func TestWritePointsOBJ(t *testing.T) {
+	points := []vectozavr.Vec3{vectozavr.NewVec3(1, 2, 3), vectozavr.NewVec3(-1, 0, 0.5), vectozavr.NewVec3(0, 0, 0)}
+	var buf bytes.Buffer
+	if err := WritePointsOBJ(&buf, "points", points); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := ReadOBJ(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 1 || len(objects[0].Mesh.Positions) != 3 || len(objects[0].Mesh.Edges) != 2 {
+		t.Fatalf("read back %v", objects)
+	}
+	for i, p := range objects[0].Mesh.Positions {
+		if p != points[i] {
+			t.Errorf("point %d read back as %v, want %v", i, p, points[i])
+		}
+	}
+
+	// A file of bare vertices is a point set
+	objects, err = ReadOBJ(strings.NewReader("v 0 0 0 1 0 0\nv 1 1 1 0 0 1\n"), nil)
+	if err != nil || len(objects) != 1 || len(objects[0].Mesh.Positions) != 2 || objects[0].Mesh.Colors[1] != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("bare vertices read as %v, %v", objects, err)
+	}
+}
+
+func vecNear(a, b vectozavr.Vec3) bool {
+	d, _ := a.Sub(b).Len()
+	return d < 1e-9
+}
+
+// Sum of the areas of the mesh's triangles
+func triangleArea(m *mesh.Mesh) float64 {
+	var area float64
+	for _, t := range m.Triangles {
+		a, b, c := m.Positions[t[0]], m.Positions[t[1]], m.Positions[t[2]]
+		l, _ := b.Sub(a).Cross(c.Sub(a)).Len()
+		area += l / 2
+	}
+	return area
+}
+
+func TestReadOBJ_ConcaveFace(t *testing.T) {
+	// An L of area 3 listed from a corner that cannot see the whole of it,
+	// tilted out of the coordinate planes
+	data := `v 2 0 0
+v 2 1 1
+v 1 1 1
+v 1 2 2
+v 0 2 2
+v 0 0 0
+f 1 2 3 4 5 6
+`
+	objects, err := ReadOBJ(strings.NewReader(data), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := objects[0].Mesh
+	if len(m.Triangles) != 4 {
+		t.Fatalf("%d triangles, want 4", len(m.Triangles))
+	}
+	if got, want := triangleArea(m), 3*math.Sqrt2; math.Abs(got-want) > 1e-9 {
+		t.Errorf("triangles cover %v, want the area of the face %v", got, want)
+	}
+	if len(m.Edges) != 6 {
+		t.Errorf("%d outline edges, want 6", len(m.Edges))
+	}
+}
@@ -0,0 +1,632 @@
+package meshio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Encoding of the data of a PLY file
+type PLYFormat int
+
+const (
+	PLYASCII PLYFormat = iota
+	PLYBinaryLittleEndian
+	PLYBinaryBigEndian
+)
+
+var plyFormatNames = []string{"ascii", "binary_little_endian", "binary_big_endian"}
+
+func (f PLYFormat) String() string {
+	if f < 0 || int(f) >= len(plyFormatNames) {
+		return fmt.Sprintf("PLYFormat(%d)", int(f))
+	}
+	return plyFormatNames[f]
+}
+
+// The contents of a PLY file: a mesh, or a point set when it has neither
+// triangles nor edges
+type PLY struct {
+	Mesh *mesh.Mesh
+	// Vertex properties the mesh has no place for, one value per vertex
+	Scalars map[string][]float64
+}
+
+// Reports whether the file holds bare points
+func (p *PLY) IsPointSet() bool {
+	return len(p.Mesh.Triangles) == 0 && len(p.Mesh.Edges) == 0
+}
+
+// A scalar type of the format with its size in bytes
+type plyType struct {
+	name string
+	size int
+	// Whether the values are integers, of which colours are 0..255
+	integer, signed bool
+}
+
+var plyTypes = map[string]plyType{
+	"char": {"char", 1, true, true}, "int8": {"char", 1, true, true},
+	"uchar": {"uchar", 1, true, false}, "uint8": {"uchar", 1, true, false},
+	"short": {"short", 2, true, true}, "int16": {"short", 2, true, true},
+	"ushort": {"ushort", 2, true, false}, "uint16": {"ushort", 2, true, false},
+	"int": {"int", 4, true, true}, "int32": {"int", 4, true, true},
+	"uint": {"uint", 4, true, false}, "uint32": {"uint", 4, true, false},
+	"float": {"float", 4, false, true}, "float32": {"float", 4, false, true},
+	"double": {"double", 8, false, true}, "float64": {"double", 8, false, true},
+}
+
+type plyProperty struct {
+	name string
+	typ  plyType
+	// A list property has a count of the count type followed by the items
+	list      bool
+	countType plyType
+}
+
+type plyElement struct {
+	name       string
+	count      int
+	properties []plyProperty
+}
+
+// Reads an ASCII or a binary PLY file. The vertex properties x, y, z,
+// nx, ny, nz, red, green, blue and u, v (or s, t) go into the mesh and
+// any other scalar into Scalars. Faces are split into triangles like OBJ
+// polygons, with their outlines as edges; an edge element adds edges.
+// Other elements are skipped.
+func ReadPLY(r io.Reader) (*PLY, error) {
+	br := bufio.NewReader(r)
+	format, elements, err := readPLYHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	var values plyValues
+	switch format {
+	case PLYASCII:
+		sc := bufio.NewScanner(br)
+		sc.Split(bufio.ScanWords)
+		values = &plyASCIIValues{sc: sc}
+	case PLYBinaryLittleEndian:
+		values = &plyBinaryValues{r: br, order: binary.LittleEndian}
+	default:
+		values = &plyBinaryValues{r: br, order: binary.BigEndian}
+	}
+
+	p := &PLY{Mesh: &mesh.Mesh{}, Scalars: map[string][]float64{}}
+	for _, e := range elements {
+		var err error
+		switch e.name {
+		case "vertex":
+			err = p.readVertices(values, e)
+		case "face":
+			err = p.readFaces(values, e)
+		case "edge":
+			err = p.readEdges(values, e)
+		default:
+			err = skipElement(values, e)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := p.Mesh.Validate(); err != nil {
+		return nil, fmt.Errorf("ply: %v", err)
+	}
+	return p, nil
+}
+
+// Reads a PLY file, see ReadPLY
+func LoadPLY(path string) (*PLY, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadPLY(f)
+}
+
+func readPLYHeader(br *bufio.Reader) (PLYFormat, []*plyElement, error) {
+	var format PLYFormat
+	var elements []*plyElement
+	sawFormat := false
+	for line := 1; ; line++ {
+		text, err := br.ReadString('\n')
+		if err != nil {
+			return 0, nil, fmt.Errorf("ply header ends without end_header at line %d", line)
+		}
+		f := strings.Fields(text)
+		if line == 1 {
+			if len(f) != 1 || f[0] != "ply" {
+				return 0, nil, fmt.Errorf("not a ply file")
+			}
+			continue
+		}
+		if len(f) == 0 {
+			continue
+		}
+		bad := func(why string) error {
+			return fmt.Errorf("ply header line %d: %s: %q", line, why, strings.TrimSpace(text))
+		}
+		switch f[0] {
+		case "format":
+			if len(f) != 3 {
+				return 0, nil, bad("bad format")
+			}
+			i := indexOf(plyFormatNames, f[1])
+			if i < 0 {
+				return 0, nil, bad("unknown format")
+			}
+			format, sawFormat = PLYFormat(i), true
+		case "element":
+			if len(f) != 3 {
+				return 0, nil, bad("bad element")
+			}
+			n, err := strconv.Atoi(f[2])
+			if err != nil || n < 0 {
+				return 0, nil, bad("bad element count")
+			}
+			elements = append(elements, &plyElement{name: f[1], count: n})
+		case "property":
+			if len(elements) == 0 {
+				return 0, nil, bad("property before any element")
+			}
+			prop, ok := parsePLYProperty(f[1:])
+			if !ok {
+				return 0, nil, bad("bad property")
+			}
+			e := elements[len(elements)-1]
+			e.properties = append(e.properties, prop)
+		case "comment", "obj_info":
+		case "end_header":
+			if !sawFormat {
+				return 0, nil, fmt.Errorf("ply header has no format")
+			}
+			return format, elements, nil
+		default:
+			return 0, nil, bad("unknown keyword")
+		}
+	}
+}
+
+// Parses "type name" or "list countType itemType name"
+func parsePLYProperty(f []string) (plyProperty, bool) {
+	if len(f) == 4 && f[0] == "list" {
+		count, ok1 := plyTypes[f[1]]
+		item, ok2 := plyTypes[f[2]]
+		if !ok1 || !ok2 || !count.integer {
+			return plyProperty{}, false
+		}
+		return plyProperty{name: f[3], typ: item, list: true, countType: count}, true
+	}
+	if len(f) != 2 {
+		return plyProperty{}, false
+	}
+	t, ok := plyTypes[f[0]]
+	return plyProperty{name: f[1], typ: t}, ok
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// A source of the numbers of the data
+type plyValues interface {
+	next(t plyType) (float64, error)
+}
+
+type plyASCIIValues struct {
+	sc *bufio.Scanner
+}
+
+func (v *plyASCIIValues) next(t plyType) (float64, error) {
+	if !v.sc.Scan() {
+		if err := v.sc.Err(); err != nil {
+			return 0, err
+		}
+		return 0, io.ErrUnexpectedEOF
+	}
+	x, err := strconv.ParseFloat(v.sc.Text(), 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q", v.sc.Text())
+	}
+	return x, nil
+}
+
+type plyBinaryValues struct {
+	r     io.Reader
+	order binary.ByteOrder
+	buf   [8]byte
+}
+
+func (v *plyBinaryValues) next(t plyType) (float64, error) {
+	b := v.buf[:t.size]
+	if _, err := io.ReadFull(v.r, b); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	switch t.name {
+	case "char":
+		return float64(int8(b[0])), nil
+	case "uchar":
+		return float64(b[0]), nil
+	case "short":
+		return float64(int16(v.order.Uint16(b))), nil
+	case "ushort":
+		return float64(v.order.Uint16(b)), nil
+	case "int":
+		return float64(int32(v.order.Uint32(b))), nil
+	case "uint":
+		return float64(v.order.Uint32(b)), nil
+	case "float":
+		return float64(math.Float32frombits(v.order.Uint32(b))), nil
+	}
+	return math.Float64frombits(v.order.Uint64(b)), nil
+}
+
+// Longest list an item may hold; faces have a handful of corners
+const maxPLYList = 1 << 16
+
+// Position of the scalar or list property in the element, -1 if it has none
+func (e *plyElement) slot(name string, list bool) int {
+	for k, prop := range e.properties {
+		if prop.name == name && prop.list == list {
+			return k
+		}
+	}
+	return -1
+}
+
+// The values of one item of an element, by the position of their property
+// in the element. The storage is reused from item to item.
+type plyItem struct {
+	scalars []float64
+	lists   [][]float64
+}
+
+func newPLYItem(e *plyElement) *plyItem {
+	return &plyItem{scalars: make([]float64, len(e.properties)), lists: make([][]float64, len(e.properties))}
+}
+
+// The scalar in the slot, zero for a missing property
+func (it *plyItem) scalar(slot int) float64 {
+	if slot < 0 {
+		return 0
+	}
+	return it.scalars[slot]
+}
+
+// Reads the values of item i of the element
+func (it *plyItem) read(values plyValues, e *plyElement, i int) error {
+	fail := func(err error) error {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("ply truncated in %s %d of %d", e.name, i, e.count)
+		}
+		return fmt.Errorf("ply %s %d: %v", e.name, i, err)
+	}
+	for k, prop := range e.properties {
+		if !prop.list {
+			x, err := values.next(prop.typ)
+			if err != nil {
+				return fail(err)
+			}
+			it.scalars[k] = x
+			continue
+		}
+		n, err := values.next(prop.countType)
+		if err != nil {
+			return fail(err)
+		}
+		if n < 0 || n != math.Trunc(n) {
+			return fail(fmt.Errorf("bad list length %v", n))
+		}
+		if n > maxPLYList {
+			return fail(fmt.Errorf("list of %v values is too long", n))
+		}
+		// Grown as the values are read, so that a bad count runs into the
+		// end of the data rather than allocating it up front
+		list := it.lists[k][:0]
+		for c := 0; c < int(n); c++ {
+			x, err := values.next(prop.typ)
+			if err != nil {
+				return fail(err)
+			}
+			list = append(list, x)
+		}
+		it.lists[k] = list
+	}
+	return nil
+}
+
+// Names of the vertex properties the mesh holds
+var plyMeshProperties = map[string]bool{
+	"x": true, "y": true, "z": true,
+	"nx": true, "ny": true, "nz": true,
+	"red": true, "green": true, "blue": true, "alpha": true,
+	"u": true, "v": true, "s": true, "t": true, "texture_u": true, "texture_v": true,
+}
+
+func (p *PLY) readVertices(values plyValues, e *plyElement) error {
+	slot := func(name string) int { return e.slot(name, false) }
+	xyz := [3]int{slot("x"), slot("y"), slot("z")}
+	for k, name := range []string{"x", "y", "z"} {
+		if xyz[k] < 0 {
+			return fmt.Errorf("ply vertices have no %s", name)
+		}
+	}
+	nx, ny, nz := slot("nx"), slot("ny"), slot("nz")
+	red, green, blue, alpha := slot("red"), slot("green"), slot("blue"), slot("alpha")
+	u, v := -1, -1
+	for _, names := range [][2]string{{"u", "v"}, {"s", "t"}, {"texture_u", "texture_v"}} {
+		if su, sv := slot(names[0]), slot(names[1]); su >= 0 && sv >= 0 {
+			u, v = su, sv
+			break
+		}
+	}
+	var extra []int
+	for k, prop := range e.properties {
+		if !prop.list && !plyMeshProperties[prop.name] {
+			extra = append(extra, k)
+		}
+	}
+
+	m := p.Mesh
+	it := newPLYItem(e)
+	c := func(slot int) uint8 {
+		if e.properties[slot].typ.integer {
+			return uint8(math.Max(0, math.Min(255, it.scalars[slot])))
+		}
+		return unit8(it.scalars[slot])
+	}
+	for i := 0; i < e.count; i++ {
+		if err := it.read(values, e, i); err != nil {
+			return err
+		}
+		m.Positions = append(m.Positions, vectozavr.NewVec3(it.scalars[xyz[0]], it.scalars[xyz[1]], it.scalars[xyz[2]]))
+		if nx >= 0 {
+			m.Normals = append(m.Normals, vectozavr.NewVec3(it.scalars[nx], it.scalar(ny), it.scalar(nz)))
+		}
+		if red >= 0 {
+			a := uint8(255)
+			if alpha >= 0 {
+				a = c(alpha)
+			}
+			var g, b uint8
+			if green >= 0 {
+				g = c(green)
+			}
+			if blue >= 0 {
+				b = c(blue)
+			}
+			m.Colors = append(m.Colors, color.RGBA{c(red), g, b, a})
+		}
+		if u >= 0 {
+			m.UVs = append(m.UVs, vectozavr.NewVec2(it.scalars[u], it.scalars[v]))
+		}
+		for _, k := range extra {
+			name := e.properties[k].name
+			p.Scalars[name] = append(p.Scalars[name], it.scalars[k])
+		}
+	}
+	return nil
+}
+
+func (p *PLY) readFaces(values plyValues, e *plyElement) error {
+	indices := e.slot("vertex_indices", true)
+	if indices < 0 {
+		indices = e.slot("vertex_index", true)
+	}
+	m := p.Mesh
+	it := newPLYItem(e)
+	for i := 0; i < e.count; i++ {
+		if err := it.read(values, e, i); err != nil {
+			return err
+		}
+		if indices < 0 {
+			return fmt.Errorf("ply face %d has no vertex indices", i)
+		}
+		idx := it.lists[indices]
+		if len(idx) < 3 {
+			return fmt.Errorf("ply face %d has %d vertices", i, len(idx))
+		}
+		corners := make([]int, len(idx))
+		for k, x := range idx {
+			corners[k] = int(x)
+		}
+		m.Triangles = append(m.Triangles, triangulate(m.Positions, corners)...)
+		for k := range corners {
+			m.Edges = append(m.Edges, [2]int{corners[k], corners[(k+1)%len(corners)]})
+		}
+	}
+	return nil
+}
+
+func (p *PLY) readEdges(values plyValues, e *plyElement) error {
+	a, b := e.slot("vertex1", false), e.slot("vertex2", false)
+	it := newPLYItem(e)
+	for i := 0; i < e.count; i++ {
+		if err := it.read(values, e, i); err != nil {
+			return err
+		}
+		if a < 0 || b < 0 {
+			return fmt.Errorf("ply edge %d has no vertex1 and vertex2", i)
+		}
+		p.Mesh.Edges = append(p.Mesh.Edges, [2]int{int(it.scalars[a]), int(it.scalars[b])})
+	}
+	return nil
+}
+
+func skipElement(values plyValues, e *plyElement) error {
+	it := newPLYItem(e)
+	for i := 0; i < e.count; i++ {
+		if err := it.read(values, e, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Writes the mesh with its normals, colours and UVs and the extra vertex
+// scalars. A mesh with triangles is written with faces, one with only
+// edges with an edge element.
+func WritePLY(w io.Writer, p *PLY, format PLYFormat) error {
+	m := p.Mesh
+	n := len(m.Positions)
+	normals := len(m.Normals) == n && n > 0
+	colors := len(m.Colors) == n && n > 0
+	uvs := len(m.UVs) == n && n > 0
+	var extra []string
+	for name, v := range p.Scalars {
+		if len(v) != n {
+			return fmt.Errorf("ply scalar %s has %d values for %d vertices", name, len(v), n)
+		}
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "ply\nformat %v 1.0\nelement vertex %d\n", format, n)
+	fmt.Fprintf(bw, "property double x\nproperty double y\nproperty double z\n")
+	if normals {
+		fmt.Fprintf(bw, "property float nx\nproperty float ny\nproperty float nz\n")
+	}
+	if colors {
+		fmt.Fprintf(bw, "property uchar red\nproperty uchar green\nproperty uchar blue\nproperty uchar alpha\n")
+	}
+	if uvs {
+		fmt.Fprintf(bw, "property float u\nproperty float v\n")
+	}
+	for _, name := range extra {
+		fmt.Fprintf(bw, "property double %s\n", name)
+	}
+	faces := len(m.Triangles) > 0
+	edges := !faces && len(m.Edges) > 0
+	if faces {
+		fmt.Fprintf(bw, "element face %d\nproperty list uchar int vertex_indices\n", len(m.Triangles))
+	}
+	if edges {
+		fmt.Fprintf(bw, "element edge %d\nproperty int vertex1\nproperty int vertex2\n", len(m.Edges))
+	}
+	fmt.Fprintf(bw, "end_header\n")
+
+	out := newPLYWriter(bw, format)
+	for i, v := range m.Positions {
+		out.put(plyTypes["double"], v.X, v.Y, v.Z)
+		if normals {
+			out.put(plyTypes["float"], m.Normals[i].X, m.Normals[i].Y, m.Normals[i].Z)
+		}
+		if colors {
+			c := m.Colors[i]
+			out.put(plyTypes["uchar"], float64(c.R), float64(c.G), float64(c.B), float64(c.A))
+		}
+		if uvs {
+			out.put(plyTypes["float"], m.UVs[i].X, m.UVs[i].Y)
+		}
+		for _, name := range extra {
+			out.put(plyTypes["double"], p.Scalars[name][i])
+		}
+		out.end()
+	}
+	if faces {
+		for _, t := range m.Triangles {
+			out.put(plyTypes["uchar"], 3)
+			out.put(plyTypes["int"], float64(t[0]), float64(t[1]), float64(t[2]))
+			out.end()
+		}
+	}
+	if edges {
+		for _, e := range m.Edges {
+			out.put(plyTypes["int"], float64(e[0]), float64(e[1]))
+			out.end()
+		}
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("cannot write ply: %v", err)
+	}
+	return nil
+}
+
+// Writes the points with their colours, if any, as a point set
+func WritePointsPLY(w io.Writer, points []vectozavr.Vec3, colors []color.RGBA, format PLYFormat) error {
+	m := &mesh.Mesh{Positions: points, Colors: colors}
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	return WritePLY(w, &PLY{Mesh: m}, format)
+}
+
+// Encodes the values of the data in the format
+type plyWriter struct {
+	w     *bufio.Writer
+	ascii bool
+	order binary.ByteOrder
+	first bool
+}
+
+func newPLYWriter(w *bufio.Writer, format PLYFormat) *plyWriter {
+	pw := &plyWriter{w: w, first: true}
+	switch format {
+	case PLYASCII:
+		pw.ascii = true
+	case PLYBinaryLittleEndian:
+		pw.order = binary.LittleEndian
+	default:
+		pw.order = binary.BigEndian
+	}
+	return pw
+}
+
+func (pw *plyWriter) put(t plyType, xs ...float64) {
+	var buf [8]byte
+	for _, x := range xs {
+		if pw.ascii {
+			if !pw.first {
+				pw.w.WriteByte(' ')
+			}
+			pw.first = false
+			if t.name == "float" {
+				pw.w.WriteString(strconv.FormatFloat(x, 'g', -1, 32))
+			} else {
+				pw.w.WriteString(ftoa(x))
+			}
+			continue
+		}
+		switch t.name {
+		case "uchar":
+			buf[0] = uint8(x)
+		case "int":
+			pw.order.PutUint32(buf[:], uint32(int32(x)))
+		case "float":
+			pw.order.PutUint32(buf[:], math.Float32bits(float32(x)))
+		default:
+			pw.order.PutUint64(buf[:], math.Float64bits(x))
+		}
+		pw.w.Write(buf[:t.size])
+	}
+}
+
+// Ends the line of an element in the ASCII format
+func (pw *plyWriter) end() {
+	if pw.ascii {
+		pw.w.WriteByte('\n')
+		pw.first = true
+	}
+}
@@ -0,0 +1,157 @@
+package meshio
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+const testPLY = `ply
+format ascii 1.0
+comment a quad with a custom property
+element vertex 4
+property float x
+property float y
+property float z
+property uchar red
+property uchar green
+property uchar blue
+property float intensity
+element face 1
+property list uchar int vertex_indices
+element camera 1
+property float view_px
+end_header
+0 0 0 255 0 0 0.5
+1 0 0 0 255 0 1
+1 1 0 0 0 255 1.5
+0 1 0 255 255 255 2
+4 0 1 2 3
+7
+`
+
+func TestReadPLY(t *testing.T) {
+	p, err := ReadPLY(strings.NewReader(testPLY))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := p.Mesh
+	if len(m.Positions) != 4 || len(m.Triangles) != 2 || len(m.Edges) != 4 || p.IsPointSet() {
+		t.Fatalf("%d vertices, %d triangles, %d edges", len(m.Positions), len(m.Triangles), len(m.Edges))
+	}
+	if m.Positions[2] != vectozavr.NewVec3(1, 1, 0) || m.Colors[1] != (color.RGBA{0, 255, 0, 255}) {
+		t.Errorf("vertex 2 at %v, vertex 1 coloured %v", m.Positions[2], m.Colors[1])
+	}
+	if got := p.Scalars["intensity"]; len(got) != 4 || got[3] != 2 {
+		t.Errorf("intensity = %v", got)
+	}
+	if m.Normals != nil || m.UVs != nil {
+		t.Errorf("mesh has normals or UVs the file has not")
+	}
+}
+
+func TestPLY_RoundTrip(t *testing.T) {
+	sphere := mesh.UVSphere(1, 8, 4)
+	sphere.SetColor(color.RGBA{10, 20, 30, 255})
+	weights := make([]float64, len(sphere.Positions))
+	for i := range weights {
+		weights[i] = float64(i) / 3
+	}
+	for _, format := range []PLYFormat{PLYASCII, PLYBinaryLittleEndian, PLYBinaryBigEndian} {
+		var buf bytes.Buffer
+		if err := WritePLY(&buf, &PLY{Mesh: sphere, Scalars: map[string][]float64{"weight": weights}}, format); err != nil {
+			t.Fatal(err)
+		}
+		p, err := ReadPLY(&buf)
+		if err != nil {
+			t.Fatalf("%v: %v", format, err)
+		}
+		m := p.Mesh
+		if len(m.Positions) != len(sphere.Positions) || len(m.Triangles) != len(sphere.Triangles) {
+			t.Fatalf("%v: %d vertices and %d triangles read back", format, len(m.Positions), len(m.Triangles))
+		}
+		for i := range m.Positions {
+			if m.Positions[i] != sphere.Positions[i] || m.Colors[i] != sphere.Colors[i] || p.Scalars["weight"][i] != weights[i] {
+				t.Fatalf("%v: vertex %d read back differently", format, i)
+			}
+			// Normals are stored in single precision
+			if d, _ := m.Normals[i].Sub(sphere.Normals[i]).Len(); d > 1e-6 {
+				t.Fatalf("%v: normal %d read back as %v, want %v", format, i, m.Normals[i], sphere.Normals[i])
+			}
+		}
+		if m.Triangles[5] != sphere.Triangles[5] {
+			t.Errorf("%v: triangle 5 = %v, want %v", format, m.Triangles[5], sphere.Triangles[5])
+		}
+	}
+}
+
+func TestWritePointsPLY(t *testing.T) {
+	points := []vectozavr.Vec3{vectozavr.NewVec3(1, 2, 3), vectozavr.NewVec3(-0.1, 0, 1e-3)}
+	colors := []color.RGBA{{255, 0, 0, 255}, {0, 0, 255, 255}}
+	var buf bytes.Buffer
+	if err := WritePointsPLY(&buf, points, colors, PLYBinaryLittleEndian); err != nil {
+		t.Fatal(err)
+	}
+	p, err := ReadPLY(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsPointSet() || len(p.Mesh.Positions) != 2 || p.Mesh.Positions[1] != points[1] || p.Mesh.Colors[1] != colors[1] {
+		t.Errorf("read back %+v", p.Mesh)
+	}
+	if err := WritePointsPLY(&buf, points, colors[:1], PLYASCII); err == nil {
+		t.Errorf("no error for fewer colours than points")
+	}
+}
+
+func TestReadPLY_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WritePointsPLY(&buf, []vectozavr.Vec3{vectozavr.NewVec3(1, 2, 3), vectozavr.NewVec3(4, 5, 6)}, nil, PLYBinaryBigEndian); err != nil {
+		t.Fatal(err)
+	}
+	full := buf.String()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "testNotPLY", data: "solid x\n", want: "not a ply file"},
+		{name: "testNoEnd", data: "ply\nformat ascii 1.0\nelement vertex 1\n", want: "without end_header"},
+		{name: "testBadFormat", data: "ply\nformat binary 1.0\nend_header\n", want: "unknown format"},
+		{name: "testBadType", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty quad x\nend_header\n", want: "bad property"},
+		{name: "testNoZ", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nend_header\n1 2\n", want: "no z"},
+		{name: "testTruncated", data: full[:len(full)-4], want: "truncated in vertex 1 of 2"},
+		{name: "testBadIndex", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nproperty float z\nelement face 1\nproperty list uchar int vertex_indices\nend_header\n0 0 0\n3 0 1 2\n", want: "refers to vertex 1"},
+		{name: "testLongList", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nproperty float z\nelement face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0\n4000000000 0 0 0\n", want: "too long"},
+		{name: "testShortList", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nproperty float z\nelement face 1\nproperty list uint int vertex_indices\nend_header\n0 0 0\n60000 0 0 0\n", want: "truncated in face 0 of 1"},
+		{name: "testBadNumber", data: "ply\nformat ascii 1.0\nelement vertex 1\nproperty float x\nproperty float y\nproperty float z\nend_header\n0 zero 0\n", want: `vertex 0: bad number "zero"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadPLY(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadPLY_ConcaveFace(t *testing.T) {
+	// An L of area 3 listed from a corner that cannot see the whole of it
+	data := "ply\nformat ascii 1.0\nelement vertex 6\nproperty float x\nproperty float y\nproperty float z\n" +
+		"element face 1\nproperty list uchar int vertex_indices\nend_header\n" +
+		"2 0 0\n2 1 0\n1 1 0\n1 2 0\n0 2 0\n0 0 0\n6 0 1 2 3 4 5\n"
+	p, err := ReadPLY(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := triangleArea(p.Mesh); math.Abs(got-3) > 1e-9 {
+		t.Errorf("triangles cover %v, want the area of the face 3", got)
+	}
+}
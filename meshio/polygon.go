@@ -0,0 +1,157 @@
+package meshio
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Splits a polygon, given by the indices of its corners in positions, into
+// triangles by ear clipping in the plane of its Newell normal, so concave
+// faces are covered exactly. Polygons that are not simple, flat to a line,
+// or have corners outside positions fall back to a fan around the first
+// corner.
+func triangulate(positions []vectozavr.Vec3, corners []int) [][3]int {
+	n := len(corners)
+	fan := func() [][3]int {
+		tris := make([][3]int, 0, n-2)
+		for i := 1; i+1 < n; i++ {
+			tris = append(tris, [3]int{corners[0], corners[i], corners[i+1]})
+		}
+		return tris
+	}
+	if n <= 3 {
+		return fan()
+	}
+	for _, c := range corners {
+		if c < 0 || c >= len(positions) {
+			return fan()
+		}
+	}
+	points, ok := projectPolygon(positions, corners)
+	if !ok || !simplePolygon(points) {
+		return fan()
+	}
+
+	// Corners left to clip, as indices into corners
+	left := make([]int, n)
+	for i := range left {
+		left[i] = i
+	}
+	tris := make([][3]int, 0, n-2)
+	for len(left) > 3 {
+		clipped := false
+		for i := range left {
+			a, b, c := left[(i+len(left)-1)%len(left)], left[i], left[(i+1)%len(left)]
+			if !isEar(points, left, a, b, c) {
+				continue
+			}
+			tris = append(tris, [3]int{corners[a], corners[b], corners[c]})
+			left = append(left[:i], left[i+1:]...)
+			clipped = true
+			break
+		}
+		if !clipped {
+			return fan()
+		}
+	}
+	return append(tris, [3]int{corners[left[0]], corners[left[1]], corners[left[2]]})
+}
+
+// The corners in the coordinate plane closest to the polygon, turning
+// counter-clockwise when it is seen from the side its Newell normal points
+// to. The flag is false for polygons with no area.
+func projectPolygon(positions []vectozavr.Vec3, corners []int) ([]vectozavr.Vec2, bool) {
+	var normal vectozavr.Vec3
+	for i, c := range corners {
+		a, b := positions[c], positions[corners[(i+1)%len(corners)]]
+		normal.X += (a.Y - b.Y) * (a.Z + b.Z)
+		normal.Y += (a.Z - b.Z) * (a.X + b.X)
+		normal.Z += (a.X - b.X) * (a.Y + b.Y)
+	}
+	ax, ay, az := math.Abs(normal.X), math.Abs(normal.Y), math.Abs(normal.Z)
+	if ax+ay+az == 0 {
+		return nil, false
+	}
+	points := make([]vectozavr.Vec2, len(corners))
+	for i, c := range corners {
+		p := positions[c]
+		switch {
+		case az >= ax && az >= ay:
+			points[i] = vectozavr.NewVec2(p.X, p.Y)
+			if normal.Z < 0 {
+				points[i] = vectozavr.NewVec2(p.Y, p.X)
+			}
+		case ax >= ay:
+			points[i] = vectozavr.NewVec2(p.Y, p.Z)
+			if normal.X < 0 {
+				points[i] = vectozavr.NewVec2(p.Z, p.Y)
+			}
+		default:
+			points[i] = vectozavr.NewVec2(p.Z, p.X)
+			if normal.Y < 0 {
+				points[i] = vectozavr.NewVec2(p.X, p.Z)
+			}
+		}
+	}
+	return points, true
+}
+
+// Twice the signed area of the triangle, positive when it turns
+// counter-clockwise
+func cross2(a, b, c vectozavr.Vec2) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+// Reports whether b is a convex corner between a and c with no other
+// corner left inside or on the triangle abc
+func isEar(points []vectozavr.Vec2, left []int, a, b, c int) bool {
+	pa, pb, pc := points[a], points[b], points[c]
+	if cross2(pa, pb, pc) <= 0 {
+		return false
+	}
+	for _, j := range left {
+		if j == a || j == b || j == c {
+			continue
+		}
+		p := points[j]
+		if cross2(pa, pb, p) >= 0 && cross2(pb, pc, p) >= 0 && cross2(pc, pa, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether no two sides of the polygon cross, other than
+// neighbours meeting at their shared corner
+func simplePolygon(points []vectozavr.Vec2) bool {
+	n := len(points)
+	for i := 0; i < n; i++ {
+		a, b := points[i], points[(i+1)%n]
+		for j := i + 1; j < n; j++ {
+			if j == i+1 || (i == 0 && j == n-1) {
+				continue
+			}
+			c, d := points[j], points[(j+1)%n]
+			if segmentsTouch(a, b, c, d) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Reports whether the segments ab and cd share a point
+func segmentsTouch(a, b, c, d vectozavr.Vec2) bool {
+	d1, d2 := cross2(a, b, c), cross2(a, b, d)
+	d3, d4 := cross2(c, d, a), cross2(c, d, b)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	on := func(p, q, r vectozavr.Vec2) bool {
+		return math.Min(p.X, q.X) <= r.X && r.X <= math.Max(p.X, q.X) &&
+			math.Min(p.Y, q.Y) <= r.Y && r.Y <= math.Max(p.Y, q.Y)
+	}
+	return (d1 == 0 && on(a, b, c)) || (d2 == 0 && on(a, b, d)) ||
+		(d3 == 0 && on(c, d, a)) || (d4 == 0 && on(c, d, b))
+}
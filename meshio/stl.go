@@ -0,0 +1,284 @@
+package meshio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Sizes of the parts of a binary STL file
+const (
+	stlHeaderSize   = 80
+	stlTriangleSize = 50
+)
+
+// Reads an ASCII or a binary STL file. Corners at the same point, up to
+// WeldTolerance, are welded into one vertex, triangles collapsed by welding are dropped and
+// the normals are recomputed from the triangles, since the facet normals
+// of many files are missing or wrong.
+func ReadSTL(r io.Reader) (*mesh.Mesh, error) {
+	br := bufio.NewReader(r)
+	w := newWelder()
+	var err error
+	if isASCIISTL(br) {
+		err = readASCIISTL(br, w)
+	} else {
+		err = readBinarySTL(br, w)
+	}
+	if err != nil {
+		return nil, err
+	}
+	w.m.ComputeNormals()
+	return w.m, nil
+}
+
+// Reads an STL file, see ReadSTL
+func LoadSTL(path string) (*mesh.Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ReadSTL(f)
+}
+
+// An ASCII file starts with "solid" and has a facet soon after. Binary
+// headers may start with "solid" too, but do not go on with facets.
+func isASCIISTL(br *bufio.Reader) bool {
+	head, _ := br.Peek(512)
+	trimmed := bytes.TrimLeft(head, " \t\r\n")
+	if !bytes.HasPrefix(trimmed, []byte("solid")) {
+		return false
+	}
+	return bytes.Contains(head, []byte("facet")) || bytes.Contains(head, []byte("endsolid"))
+}
+
+// Size of the grid STL corners are snapped to for welding. Corners in the
+// same cell become one vertex, at the first of them read; corners closer
+// than this across a cell border stay apart.
+const WeldTolerance = 1e-5
+
+// Builds a mesh of triangles merging corners at the same point
+type welder struct {
+	m     *mesh.Mesh
+	index map[[3]int64]int
+}
+
+func newWelder() *welder {
+	return &welder{m: &mesh.Mesh{}, index: map[[3]int64]int{}}
+}
+
+func (w *welder) vertex(p vectozavr.Vec3) int {
+	key := [3]int64{
+		int64(math.Round(p.X / WeldTolerance)),
+		int64(math.Round(p.Y / WeldTolerance)),
+		int64(math.Round(p.Z / WeldTolerance)),
+	}
+	if i, ok := w.index[key]; ok {
+		return i
+	}
+	w.m.Positions = append(w.m.Positions, p)
+	w.index[key] = len(w.m.Positions) - 1
+	return w.index[key]
+}
+
+func (w *welder) triangle(a, b, c vectozavr.Vec3) {
+	i, j, k := w.vertex(a), w.vertex(b), w.vertex(c)
+	if i == j || j == k || k == i {
+		return
+	}
+	w.m.Triangles = append(w.m.Triangles, [3]int{i, j, k})
+}
+
+func readASCIISTL(r io.Reader, w *welder) error {
+	sc := bufio.NewScanner(r)
+	line := 0
+	// The next non-empty line split into words, or nil at the end of the file
+	next := func() []string {
+		for sc.Scan() {
+			line++
+			if f := strings.Fields(sc.Text()); len(f) > 0 {
+				return f
+			}
+		}
+		return nil
+	}
+	fail := func(f []string, want string) error {
+		if err := sc.Err(); err != nil {
+			return fmt.Errorf("cannot read stl: %v", err)
+		}
+		if f == nil {
+			return fmt.Errorf("ascii stl truncated at line %d: want %q", line, want)
+		}
+		return fmt.Errorf("ascii stl line %d: want %q, got %q", line, want, strings.Join(f, " "))
+	}
+	expect := func(want ...string) error {
+		f := next()
+		if len(f) < len(want) {
+			return fail(f, strings.Join(want, " "))
+		}
+		for i, word := range want {
+			if !strings.EqualFold(f[i], word) {
+				return fail(f, strings.Join(want, " "))
+			}
+		}
+		return nil
+	}
+
+	f := next()
+	if f == nil || !strings.EqualFold(f[0], "solid") {
+		return fail(f, "solid")
+	}
+	for {
+		f = next()
+		switch {
+		case f == nil:
+			return fail(f, "endsolid")
+		case strings.EqualFold(f[0], "endsolid"):
+			// Another solid may follow
+			if f = next(); f == nil {
+				return sc.Err()
+			}
+			if !strings.EqualFold(f[0], "solid") {
+				return fail(f, "solid")
+			}
+			continue
+		case !strings.EqualFold(f[0], "facet"):
+			return fail(f, "facet normal")
+		}
+		if err := expect("outer", "loop"); err != nil {
+			return err
+		}
+		var corners [3]vectozavr.Vec3
+		for i := range corners {
+			f = next()
+			if len(f) != 4 || !strings.EqualFold(f[0], "vertex") {
+				return fail(f, "vertex x y z")
+			}
+			v, err := parseFloats(f[1:], 3, 3)
+			if err != nil {
+				return fmt.Errorf("ascii stl line %d: %v", line, err)
+			}
+			corners[i] = vectozavr.NewVec3(v[0], v[1], v[2])
+		}
+		if err := expect("endloop"); err != nil {
+			return err
+		}
+		if err := expect("endfacet"); err != nil {
+			return err
+		}
+		w.triangle(corners[0], corners[1], corners[2])
+	}
+}
+
+func readBinarySTL(r io.Reader, w *welder) error {
+	var header [stlHeaderSize + 4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return fmt.Errorf("binary stl truncated in the header: %v", err)
+	}
+	count := binary.LittleEndian.Uint32(header[stlHeaderSize:])
+	var buf [stlTriangleSize]byte
+	for n := uint32(0); n < count; n++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return fmt.Errorf("binary stl truncated after %d of %d triangles", n, count)
+			}
+			return fmt.Errorf("cannot read stl: %v", err)
+		}
+		var corners [3]vectozavr.Vec3
+		for i := range corners {
+			// The facet normal comes first
+			off := 12 * (i + 1)
+			x := math.Float32frombits(binary.LittleEndian.Uint32(buf[off:]))
+			y := math.Float32frombits(binary.LittleEndian.Uint32(buf[off+4:]))
+			z := math.Float32frombits(binary.LittleEndian.Uint32(buf[off+8:]))
+			if isBad(x) || isBad(y) || isBad(z) {
+				return fmt.Errorf("binary stl triangle %d has a corner at (%v, %v, %v)", n, x, y, z)
+			}
+			corners[i] = vectozavr.NewVec3(float64(x), float64(y), float64(z))
+		}
+		w.triangle(corners[0], corners[1], corners[2])
+	}
+	var extra [1]byte
+	if n, _ := io.ReadFull(r, extra[:]); n > 0 {
+		return fmt.Errorf("binary stl has data after its %d triangles", count)
+	}
+	return nil
+}
+
+func isBad(x float32) bool {
+	return math.IsNaN(float64(x)) || math.IsInf(float64(x), 0)
+}
+
+// The unit normal of a triangle, zero for a degenerate one
+func facetNormal(a, b, c vectozavr.Vec3) vectozavr.Vec3 {
+	n, err := b.Sub(a).Cross(c.Sub(a)).Normalize()
+	if err != nil {
+		return vectozavr.ZeroVec3()
+	}
+	return n
+}
+
+// Writes the triangles of the mesh as an ASCII STL solid
+func WriteSTL(w io.Writer, m *mesh.Mesh, name string) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "solid %s\n", name)
+	for _, t := range m.Triangles {
+		a, b, c := m.Positions[t[0]], m.Positions[t[1]], m.Positions[t[2]]
+		n := facetNormal(a, b, c)
+		fmt.Fprintf(bw, "  facet normal %s %s %s\n    outer loop\n", ftoa(n.X), ftoa(n.Y), ftoa(n.Z))
+		for _, p := range []vectozavr.Vec3{a, b, c} {
+			fmt.Fprintf(bw, "      vertex %s %s %s\n", ftoa(p.X), ftoa(p.Y), ftoa(p.Z))
+		}
+		fmt.Fprintf(bw, "    endloop\n  endfacet\n")
+	}
+	fmt.Fprintf(bw, "endsolid %s\n", name)
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("cannot write stl: %v", err)
+	}
+	return nil
+}
+
+// Writes the triangles of the mesh as a binary STL file with the header
+// text, cut to 80 bytes. Coordinates are stored in single precision.
+func WriteBinarySTL(w io.Writer, m *mesh.Mesh, header string) error {
+	if strings.HasPrefix(strings.TrimSpace(header), "solid") {
+		// Readers would take the file for an ASCII one
+		header = "binary " + header
+	}
+	bw := bufio.NewWriter(w)
+	var head [stlHeaderSize + 4]byte
+	copy(head[:stlHeaderSize], header)
+	binary.LittleEndian.PutUint32(head[stlHeaderSize:], uint32(len(m.Triangles)))
+	bw.Write(head[:])
+
+	var buf [stlTriangleSize]byte
+	put := func(off int, v vectozavr.Vec3) {
+		binary.LittleEndian.PutUint32(buf[off:], math.Float32bits(float32(v.X)))
+		binary.LittleEndian.PutUint32(buf[off+4:], math.Float32bits(float32(v.Y)))
+		binary.LittleEndian.PutUint32(buf[off+8:], math.Float32bits(float32(v.Z)))
+	}
+	for _, t := range m.Triangles {
+		a, b, c := m.Positions[t[0]], m.Positions[t[1]], m.Positions[t[2]]
+		put(0, facetNormal(a, b, c))
+		put(12, a)
+		put(24, b)
+		put(36, c)
+		// The attribute byte count is unused
+		buf[48], buf[49] = 0, 0
+		bw.Write(buf[:])
+	}
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("cannot write stl: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package meshio
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestSTL_RoundTrip(t *testing.T) {
+	box := mesh.Box(vectozavr.NewVec3(1, 2, 3))
+	for _, binary := range []bool{false, true} {
+		var buf bytes.Buffer
+		var err error
+		if binary {
+			err = WriteBinarySTL(&buf, box, "solid box")
+		} else {
+			err = WriteSTL(&buf, box, "box")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := ReadSTL(&buf)
+		if err != nil {
+			t.Fatalf("binary %v: %v", binary, err)
+		}
+		// The 24 corners of the faces weld into the 8 corners of the box
+		if len(m.Positions) != 8 || len(m.Triangles) != 12 {
+			t.Errorf("binary %v: %d vertices and %d triangles, want 8 and 12", binary, len(m.Positions), len(m.Triangles))
+		}
+		if b := m.Bounds(); !vecNear(b.Max, vectozavr.NewVec3(0.5, 1, 1.5)) || !vecNear(b.Min, vectozavr.NewVec3(-0.5, -1, -1.5)) {
+			t.Errorf("binary %v: bounds %v", binary, b)
+		}
+		// Recomputed normals point away from the centre
+		for i, n := range m.Normals {
+			if n.Dot(m.Positions[i]) <= 0 {
+				t.Errorf("binary %v: normal %v at %v points inwards", binary, n, m.Positions[i])
+			}
+		}
+	}
+}
+
+func TestSTL_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteBinarySTL(&buf, mesh.Cube(1), ""); err != nil {
+		t.Fatal(err)
+	}
+	full := buf.Bytes()
+
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "testShortHeader", data: string(full[:40]), want: "header"},
+		{name: "testTruncated", data: string(full[:len(full)-10]), want: "after 11 of 12"},
+		{name: "testTrailing", data: string(full) + "\x00\x00", want: "data after its 12 triangles"},
+		{name: "testNoLoop", data: "solid x\nfacet normal 0 0 1\nvertex 0 0 0\n", want: `line 3: want "outer loop"`},
+		{name: "testBadNumber", data: "solid x\nfacet normal 0 0 1\nouter loop\nvertex 0 a 0\n", want: `line 4: bad number "a"`},
+		{name: "testTruncatedASCII", data: "solid x\nfacet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\n", want: "truncated"},
+		{name: "testNoEnd", data: "solid x\nfacet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 0 1 0\nendloop\nendfacet\n", want: "endsolid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadSTL(strings.NewReader(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadSTL_Weld(t *testing.T) {
+	// The second triangle's corners are off by less than the tolerance
+	const d = WeldTolerance / 10
+	data := "solid x\n" +
+		"facet normal 0 0 1\nouter loop\nvertex 0 0 0\nvertex 1 0 0\nvertex 0 1 0\nendloop\nendfacet\n" +
+		fmt.Sprintf("facet normal 0 0 1\nouter loop\nvertex %v 0 0\nvertex 1 1 0\nvertex 0 %v 0\nendloop\nendfacet\n", 1+d, 1-d) +
+		"endsolid x\n"
+	m, err := ReadSTL(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Positions) != 4 || len(m.Triangles) != 2 {
+		t.Errorf("%d vertices and %d triangles, want 4 and 2", len(m.Positions), len(m.Triangles))
+	}
+}
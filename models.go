@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/meshio"
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Adds the objects of a model file to the scene, picking the format by
+// the extension
+func (g *Game) loadModel(path string) error {
+	var objects []*object.Object
+	var err error
+	frame := modelFrame(g.conv)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".obj":
+		objects, err = meshio.LoadOBJ(path)
+	case ".stl":
+		var m *mesh.Mesh
+		if m, err = meshio.LoadSTL(path); err == nil {
+			o := object.NewObject(vectozavr.Identity())
+			o.Mesh = m
+			objects = []*object.Object{o}
+		}
+		frame = g.stlFrame()
+	case ".ply":
+		var p *meshio.PLY
+		if p, err = meshio.LoadPLY(path); err == nil {
+			if g.pointClouds {
+				p.Mesh.Triangles, p.Mesh.Edges = nil, nil
+			}
+			colorByScalar(p)
+			o := object.NewObject(vectozavr.Identity())
+			o.Mesh = p.Mesh
+			objects = []*object.Object{o}
+		}
+	default:
+		return fmt.Errorf("unknown model format %q", ext)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot load %s: %v", path, err)
+	}
+	for _, o := range objects {
+		if o.Name == "" {
+			o.Name = filepath.Base(path)
+		}
+		o.SetTransform(frame)
+	}
+	g.objects = append(g.objects, objects...)
+	return nil
+}
+
+// Colours a mesh without vertex colours by the first of its extra vertex
+// properties in name order, from blue at the lowest value to red at the
+// highest. The other properties are not shown.
+func colorByScalar(p *meshio.PLY) {
+	m := p.Mesh
+	if len(m.Colors) == len(m.Positions) || len(p.Scalars) == 0 {
+		return
+	}
+	names := make([]string, 0, len(p.Scalars))
+	for name := range p.Scalars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := p.Scalars[names[0]]
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		lo, hi = math.Min(lo, v), math.Max(hi, v)
+	}
+	m.Colors = make([]color.RGBA, len(values))
+	for i, v := range values {
+		u := 0.5
+		if hi > lo {
+			u = (v - lo) / (hi - lo)
+		}
+		m.Colors[i] = color.RGBA{uint8(255 * u), 64, uint8(255 * (1 - u)), 255}
+	}
+}
+
+// STL files are made with Z up, as printers take them
+func (g *Game) stlFrame() vectozavr.Matrix {
+	zUp := vectozavr.NewMatrixVec3(vectozavr.NewVec3(1, 0, 0), vectozavr.NewVec3(0, 0, -1), vectozavr.NewVec3(0, 1, 0))
+	return modelFrame(g.conv).MatMul(zUp)
+}
+
+// The objects of the scene, with the ones attached to the movers
+func (g *Game) sceneObjects() []*object.Object {
+	objects := append([]*object.Object{}, g.objects...)
+	for _, m := range g.movers {
+		m.obj.Walk(func(o *object.Object, depth int) bool {
+			objects = append(objects, o)
+			return true
+		})
+	}
+	return objects
+}
+
+// The meshes of the scene merged in the world coordinates taken to the
+// coordinates of a file by the inverse of its frame
+func (g *Game) sceneMesh(frame vectozavr.Matrix) (*mesh.Mesh, error) {
+	toFile, err := frame.Inverse()
+	if err != nil {
+		return nil, err
+	}
+	scene := &mesh.Mesh{}
+	for _, o := range g.sceneObjects() {
+		if o.Mesh == nil {
+			continue
+		}
+		m := o.Mesh.Clone()
+		m.Transform(toFile.MatMul(o.World()))
+		scene.Append(m)
+	}
+	return scene, nil
+}
+
+// X exports the clicked points, Alt+X the objects of the scene and their
+// triangles for printing, into files named after the scene in the
+// coordinates loadModel reads them in.
+// The points go both into an OBJ polyline and a PLY point set coloured
+// by their grid planes.
+func (g *Game) exportKeys() error {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyX) {
+		return nil
+	}
+	// The inverse of the frame the files are loaded with
+	toFile, err := modelFrame(g.conv).Inverse()
+	if err != nil {
+		return err
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyAlt) {
+		if err := writeFile(g.scene+".obj", func(f *os.File) error {
+			return meshio.WriteOBJ(f, g.sceneObjects(), toFile)
+		}); err != nil {
+			return err
+		}
+		m, err := g.sceneMesh(g.stlFrame())
+		if err != nil {
+			return err
+		}
+		return writeFile(g.scene+".stl", func(f *os.File) error {
+			return meshio.WriteBinarySTL(f, m, g.scene)
+		})
+	}
+	var clicked []vectozavr.Vec3
+	for _, p := range g.allClicked() {
+		clicked = append(clicked, toFile.Vec4Mul(p.ToVec4()).ToVec3())
+	}
+	if err := writeFile(g.scene+".points.obj", func(f *os.File) error {
+		return meshio.WritePointsOBJ(f, "points", clicked)
+	}); err != nil {
+		return err
+	}
+	var points []vectozavr.Vec3
+	var colors []color.RGBA
+	for axis, clr := range gridColors {
+		for _, p := range *g.points(axis) {
+			points = append(points, toFile.Vec4Mul(p.ToVec4()).ToVec3())
+			colors = append(colors, color.RGBAModel.Convert(clr).(color.RGBA))
+		}
+	}
+	return writeFile(g.scene+".points.ply", func(f *os.File) error {
+		return meshio.WritePointsPLY(f, points, colors, meshio.PLYBinaryLittleEndian)
+	})
+}
+
+// Creates the file and writes it with write
+func writeFile(name string, write func(f *os.File) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
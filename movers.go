@@ -0,0 +1,146 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rudolfkova/vectozavr/camera"
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Half the edge of a mover's cube
+const moverSize = 0.3
+
+// An object driven along a closed curve, facing where it goes
+type mover struct {
+	obj *object.Object
+	// Position at the time in seconds, in the ground coordinates:
+	// X along the side axis, Y up and Z forward
+	path func(t float64) vectozavr.Vec3
+}
+
+// A mover circling the origin and one tracing a figure eight while bobbing up and down
+func newMovers() []*mover {
+	return []*mover{
+		newMover("circle", func(t float64) vectozavr.Vec3 {
+			a := t * 0.6
+			return vectozavr.NewVec3(3*math.Cos(a), moverSize, 3*math.Sin(a))
+		}),
+		newMover("eight", func(t float64) vectozavr.Vec3 {
+			a := t * 0.4
+			return vectozavr.NewVec3(4*math.Sin(a), 1+0.5*math.Sin(3*a), 2*math.Sin(2*a))
+		}),
+	}
+}
+
+// Creates a mover with a small flag riding on top of it
+func newMover(name string, path func(t float64) vectozavr.Vec3) *mover {
+	obj := object.NewObject(vectozavr.Identity())
+	obj.Name = name
+	obj.Mesh = wedgeMesh()
+	flag := object.NewObject(vectozavr.Identity())
+	flag.Name = "flag"
+	flag.Mesh = mesh.Quad(2, 1)
+	if err := obj.AddChild(flag); err != nil {
+		panic(err)
+	}
+	// In the mover's coordinates, where its cube spans [-1, 1]
+	flag.Scale(vectozavr.NewVec3(0.3, 0.3, 0.3))
+	flag.Translate(vectozavr.NewVec3(0, 2, -0.7))
+	return &mover{obj: obj, path: path}
+}
+
+// A four-sided pyramid with the base at the back of the unit cube and the
+// tip ahead of it along Z, the tip in yellow
+func wedgeMesh() *mesh.Mesh {
+	m := mesh.NewMesh([]vectozavr.Vec3{
+		vectozavr.NewVec3(0, 0, 1.5),
+		vectozavr.NewVec3(-1, -1, -1),
+		vectozavr.NewVec3(1, -1, -1),
+		vectozavr.NewVec3(1, 1, -1),
+		vectozavr.NewVec3(-1, 1, -1),
+	}, [][3]int{
+		{0, 1, 2}, {0, 2, 3}, {0, 3, 4}, {0, 4, 1},
+		{1, 3, 2}, {1, 4, 3},
+	})
+	m.Colors = []color.RGBA{{255, 255, 0, 255}, {200, 200, 200, 255}, {200, 200, 200, 255}, {200, 200, 200, 255}, {200, 200, 200, 255}}
+	return m
+}
+
+// Moves the mover to its place at time t, its Z axis along the direction
+// of motion, its Y axis up and its X axis to the side
+func (m *mover) update(conv vectozavr.Conventions, t float64) {
+	side, up, fwd := conv.SideVector(), conv.UpVector(), conv.ForwardVector()
+	world := func(p vectozavr.Vec3) vectozavr.Vec3 {
+		return side.Mul(p.X).Add(up.Mul(p.Y)).Add(fwd.Mul(p.Z))
+	}
+	pos := world(m.path(t))
+	m.obj.TranslateToPoint(pos)
+
+	const dt = 1e-3
+	dir := world(m.path(t + dt)).Sub(pos)
+	dir = dir.Sub(up.Mul(dir.Dot(up)))
+	z, err := dir.Normalize()
+	if err != nil {
+		z = fwd
+	}
+	x := up.Cross(z)
+	m.obj.SetTransform(vectozavr.NewMatrixVec3(x.Mul(moverSize), up.Mul(moverSize), z.Mul(moverSize)))
+}
+
+// Draws the mover and the objects attached to it, with the names of the attached ones
+func (g *Game) drawMover(screen *ebiten.Image, cam *camera.Camera, m *mover) {
+	m.obj.Walk(func(o *object.Object, depth int) bool {
+		g.drawObject(screen, cam, o)
+		if depth > 0 {
+			if p, ok := cam.WorldToScreen(o.WorldPos(), cam.Viewport); ok {
+				ebitenutil.DebugPrintAt(screen, o.Path(), int(p.X)+4, int(p.Y)-20)
+			}
+		}
+		return true
+	})
+}
+
+// Advances the movers to the current time
+func (g *Game) updateMovers() {
+	g.time += g.dt()
+	for _, m := range g.movers {
+		m.update(g.conv, g.time)
+	}
+}
+
+// The movers as obstacles for the camera
+func (g *Game) moverColliders() []camera.Collider {
+	var cols []camera.Collider
+	for _, m := range g.movers {
+		cols = append(cols, camera.ObjectCollider{Object: m.obj})
+	}
+	return cols
+}
+
+// G makes the perspective camera follow the next mover, and after the
+// last one stops following
+func (g *Game) followKeys() {
+	if !inpututil.IsKeyJustPressed(ebiten.KeyG) {
+		return
+	}
+	cam := g.persp.cam
+	next := 0
+	if cam.Mode == camera.ModeFollow {
+		for i, m := range g.movers {
+			if m.obj == cam.Follow.Target {
+				next = i + 1
+			}
+		}
+	}
+	if next >= len(g.movers) {
+		cam.FollowObject(nil)
+		return
+	}
+	cam.FollowObject(g.movers[next].obj)
+}
@@ -0,0 +1,143 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Noise functions over 2D, 3D and 4D points, e.g. (*Noise).Perlin3
+type (
+	Func2 func(vectozavr.Vec2) float64
+	Func3 func(vectozavr.Vec3) float64
+	Func4 func(vectozavr.Vec4) float64
+)
+
+// Noise functions returning an analytic gradient, e.g. (*Noise).Simplex3Grad
+type (
+	GradFunc2 func(vectozavr.Vec2) (float64, vectozavr.Vec2)
+	GradFunc3 func(vectozavr.Vec3) (float64, vectozavr.Vec3)
+)
+
+// Parameters of a fractal sum of noise octaves
+type Fractal struct {
+	Octaves    int
+	Lacunarity float64 // frequency multiplier between octaves
+	Gain       float64 // amplitude multiplier between octaves
+}
+
+// Five octaves, doubling the frequency and halving the amplitude each time
+func DefaultFractal() Fractal {
+	return Fractal{Octaves: 5, Lacunarity: 2, Gain: 0.5}
+}
+
+// Calls octave for every octave with its frequency and amplitude and returns the amplitude sum
+func (f Fractal) each(octave func(freq, amp float64)) float64 {
+	freq, amp, sum := 1.0, 1.0, 0.0
+	for i := 0; i < f.Octaves; i++ {
+		octave(freq, amp)
+		sum += amp
+		freq *= f.Lacunarity
+		amp *= f.Gain
+	}
+	if sum == 0 {
+		return 1
+	}
+	return sum
+}
+
+// Fractal Brownian motion: normalised sum of octaves of fn
+func (f Fractal) FBM2(fn Func2, p vectozavr.Vec2) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * fn(p.Mul(freq)) })
+	return v / norm
+}
+
+func (f Fractal) FBM3(fn Func3, p vectozavr.Vec3) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * fn(p.Mul(freq)) })
+	return v / norm
+}
+
+func (f Fractal) FBM4(fn Func4, p vectozavr.Vec4) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * fn(p.Mul(freq)) })
+	return v / norm
+}
+
+// Fractal Brownian motion together with its analytic gradient
+func (f Fractal) FBM2Grad(fn GradFunc2, p vectozavr.Vec2) (float64, vectozavr.Vec2) {
+	var v float64
+	var g vectozavr.Vec2
+	norm := f.each(func(freq, amp float64) {
+		ov, og := fn(p.Mul(freq))
+		v += amp * ov
+		g = g.Add(og.Mul(amp * freq))
+	})
+	return v / norm, g.Mul(1 / norm)
+}
+
+func (f Fractal) FBM3Grad(fn GradFunc3, p vectozavr.Vec3) (float64, vectozavr.Vec3) {
+	var v float64
+	var g vectozavr.Vec3
+	norm := f.each(func(freq, amp float64) {
+		ov, og := fn(p.Mul(freq))
+		v += amp * ov
+		g = g.Add(og.Mul(amp * freq))
+	})
+	return v / norm, g.Mul(1 / norm)
+}
+
+// Turbulence: normalised sum of absolute octaves of fn, in [0, 1]
+func (f Fractal) Turbulence2(fn Func2, p vectozavr.Vec2) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * math.Abs(fn(p.Mul(freq))) })
+	return v / norm
+}
+
+func (f Fractal) Turbulence3(fn Func3, p vectozavr.Vec3) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * math.Abs(fn(p.Mul(freq))) })
+	return v / norm
+}
+
+func (f Fractal) Turbulence4(fn Func4, p vectozavr.Vec4) float64 {
+	var v float64
+	norm := f.each(func(freq, amp float64) { v += amp * math.Abs(fn(p.Mul(freq))) })
+	return v / norm
+}
+
+// Offsets decorrelating the components of the warp vector
+var (
+	warpOffset1 = vectozavr.NewVec4(5.2, 1.3, 7.7, 3.1)
+	warpOffset2 = vectozavr.NewVec4(1.7, 9.2, 2.8, 6.4)
+	warpOffset3 = vectozavr.NewVec4(8.3, 2.8, 4.6, 0.9)
+)
+
+// Domain warping: samples fn at p displaced by amount times a noise vector built from fn itself
+func Warp2(fn Func2, p vectozavr.Vec2, amount float64) float64 {
+	q := vectozavr.NewVec2(
+		fn(p),
+		fn(p.Add(warpOffset1.ToVec2())),
+	)
+	return fn(p.Add(q.Mul(amount)))
+}
+
+func Warp3(fn Func3, p vectozavr.Vec3, amount float64) float64 {
+	q := vectozavr.NewVec3(
+		fn(p),
+		fn(p.Add(warpOffset1.ToVec3())),
+		fn(p.Add(warpOffset2.ToVec3())),
+	)
+	return fn(p.Add(q.Mul(amount)))
+}
+
+func Warp4(fn Func4, p vectozavr.Vec4, amount float64) float64 {
+	q := vectozavr.NewVec4(
+		fn(p),
+		fn(p.Add(warpOffset1)),
+		fn(p.Add(warpOffset2)),
+		fn(p.Add(warpOffset3)),
+	)
+	return fn(p.Add(q.Mul(amount)))
+}
@@ -0,0 +1,60 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestFractal_Golden(t *testing.T) {
+	n := New(42)
+	f := DefaultFractal()
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{name: "fbm", got: f.FBM3(n.Perlin3, vectozavr.NewVec3(3.7, -1.2, 2.2)), want: 0.14249822828816167},
+		{name: "turbulence", got: f.Turbulence3(n.Simplex3, vectozavr.NewVec3(3.7, -1.2, 2.2)), want: 0.33951071869162347},
+		{name: "warp", got: Warp2(n.Perlin2, vectozavr.NewVec2(3.7, -1.2), 0.5), want: 0.1328687247571374},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.want) > eps {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFractal_SingleOctave(t *testing.T) {
+	n := New(3)
+	f := Fractal{Octaves: 1, Lacunarity: 2, Gain: 0.5}
+	p := vectozavr.NewVec3(1.3, 2.7, -0.6)
+	if got, want := f.FBM3(n.Simplex3, p), n.Simplex3(p); got != want {
+		t.Errorf("FBM3() = %v, want %v", got, want)
+	}
+	if got, want := f.Turbulence3(n.Simplex3, p), math.Abs(n.Simplex3(p)); got != want {
+		t.Errorf("Turbulence3() = %v, want %v", got, want)
+	}
+}
+
+func TestFractal_FBM3Grad(t *testing.T) {
+	n := New(42)
+	f := DefaultFractal()
+	p := vectozavr.NewVec3(0.3, 1.9, -2.4)
+	const h = 1e-6
+	v, g := f.FBM3Grad(n.Perlin3Grad, p)
+	if want := f.FBM3(n.Perlin3, p); math.Abs(v-want) > eps {
+		t.Errorf("FBM3Grad() value = %v, want %v", v, want)
+	}
+	axes := []vectozavr.Vec3{{X: h}, {Y: h}, {Z: h}}
+	want := []float64{g.X, g.Y, g.Z}
+	for i, d := range axes {
+		fd := (f.FBM3(n.Perlin3, p.Add(d)) - f.FBM3(n.Perlin3, p.Sub(d))) / (2 * h)
+		if math.Abs(fd-want[i]) > 1e-5 {
+			t.Errorf("gradient axis %d = %v, finite difference %v", i, want[i], fd)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package noise
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// A coherent noise generator with a seeded permutation table
+type Noise struct {
+	perm [512]uint8
+}
+
+// Creates a noise generator whose permutation table is shuffled by the seed
+func New(seed uint64) *Noise {
+	n := &Noise{}
+	r := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	p := r.Perm(256)
+	for i := 0; i < 512; i++ {
+		n.perm[i] = uint8(p[i&255])
+	}
+	return n
+}
+
+// Hashes lattice coordinates into [0, 255]
+func (n *Noise) hash2(x, y int) int {
+	return int(n.perm[int(n.perm[x&255])+y&255])
+}
+
+func (n *Noise) hash3(x, y, z int) int {
+	return int(n.perm[n.hash2(x, y)+z&255])
+}
+
+func (n *Noise) hash4(x, y, z, w int) int {
+	return int(n.perm[n.hash3(x, y, z)+w&255])
+}
+
+// Quintic fade curve 6t^5 - 15t^4 + 10t^3
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+// Derivative of the fade curve
+func dfade(t float64) float64 {
+	return 30 * t * t * (t*(t-2) + 1)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + t*(b-a)
+}
+
+// Splits a coordinate into the lattice cell and the offset inside it
+func cell(x float64) (int, float64) {
+	f := math.Floor(x)
+	return int(f), x - f
+}
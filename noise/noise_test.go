@@ -0,0 +1,152 @@
+package noise
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+const eps = 1e-12
+
+func TestNoise_Golden2(t *testing.T) {
+	n := New(42)
+	tests := []struct {
+		name string
+		fn   Func2
+		p    vectozavr.Vec2
+		want float64
+	}{
+		{name: "perlin1", fn: n.Perlin2, p: vectozavr.Vec2{X: 0.5, Y: 0.25}, want: 0.435302734375},
+		{name: "perlin2", fn: n.Perlin2, p: vectozavr.Vec2{X: 3.7, Y: -1.2}, want: 0.3894692031999998},
+		{name: "perlin3", fn: n.Perlin2, p: vectozavr.Vec2{X: -12.3, Y: 8.9}, want: 0.048558280320000424},
+		{name: "simplex1", fn: n.Simplex2, p: vectozavr.Vec2{X: 0.5, Y: 0.25}, want: -0.0006276319008981148},
+		{name: "simplex2", fn: n.Simplex2, p: vectozavr.Vec2{X: 3.7, Y: -1.2}, want: 0.8070813325638982},
+		{name: "simplex3", fn: n.Simplex2, p: vectozavr.Vec2{X: -12.3, Y: 8.9}, want: -0.4752673977043452},
+		{name: "value1", fn: n.Value2, p: vectozavr.Vec2{X: 0.5, Y: 0.25}, want: -0.6957950367647058},
+		{name: "value2", fn: n.Value2, p: vectozavr.Vec2{X: 3.7, Y: -1.2}, want: -0.6953781181741179},
+		{name: "value3", fn: n.Value2, p: vectozavr.Vec2{X: -12.3, Y: 8.9}, want: 0.3702189653082354},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.p); math.Abs(got-tt.want) > eps {
+				t.Errorf("noise(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoise_Golden3(t *testing.T) {
+	n := New(42)
+	tests := []struct {
+		name string
+		fn   Func3
+		p    vectozavr.Vec3
+		want float64
+	}{
+		{name: "perlin1", fn: n.Perlin3, p: vectozavr.Vec3{X: 0.5, Y: 0.25, Z: 0.75}, want: -0.3750786781311035},
+		{name: "perlin2", fn: n.Perlin3, p: vectozavr.Vec3{X: 3.7, Y: -1.2, Z: 2.2}, want: 0.2721448684949504},
+		{name: "perlin3", fn: n.Perlin3, p: vectozavr.Vec3{X: -12.3, Y: 8.9, Z: -0.4}, want: -0.052211968559000344},
+		{name: "simplex1", fn: n.Simplex3, p: vectozavr.Vec3{X: 0.5, Y: 0.25, Z: 0.75}, want: -0.04100624999999965},
+		{name: "simplex2", fn: n.Simplex3, p: vectozavr.Vec3{X: 3.7, Y: -1.2, Z: 2.2}, want: -0.15208584335802458},
+		{name: "simplex3", fn: n.Simplex3, p: vectozavr.Vec3{X: -12.3, Y: 8.9, Z: -0.4}, want: -0.20618221511111084},
+		{name: "value1", fn: n.Value3, p: vectozavr.Vec3{X: 0.5, Y: 0.25, Z: 0.75}, want: -0.1884292901731005},
+		{name: "value2", fn: n.Value3, p: vectozavr.Vec3{X: 3.7, Y: -1.2, Z: 2.2}, want: 0.40344462965678896},
+		{name: "value3", fn: n.Value3, p: vectozavr.Vec3{X: -12.3, Y: 8.9, Z: -0.4}, want: -0.29513015959404165},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.p); math.Abs(got-tt.want) > eps {
+				t.Errorf("noise(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoise_Golden4(t *testing.T) {
+	n := New(42)
+	tests := []struct {
+		name string
+		fn   Func4
+		p    vectozavr.Vec4
+		want float64
+	}{
+		{name: "perlin1", fn: n.Perlin4, p: vectozavr.Vec4{X: 0.5, Y: 0.25, Z: 0.75, W: 0.1}, want: -0.25234172675323485},
+		{name: "perlin2", fn: n.Perlin4, p: vectozavr.Vec4{X: 3.7, Y: -1.2, Z: 2.2, W: 5.5}, want: -0.030067106870477145},
+		{name: "simplex1", fn: n.Simplex4, p: vectozavr.Vec4{X: 0.5, Y: 0.25, Z: 0.75, W: 0.1}, want: -0.18631759378578555},
+		{name: "simplex2", fn: n.Simplex4, p: vectozavr.Vec4{X: -12.3, Y: 8.9, Z: -0.4, W: -3.3}, want: 0.5954547542134907},
+		{name: "value1", fn: n.Value4, p: vectozavr.Vec4{X: 0.5, Y: 0.25, Z: 0.75, W: 0.1}, want: 0.6966422407609528},
+		{name: "value2", fn: n.Value4, p: vectozavr.Vec4{X: -12.3, Y: 8.9, Z: -0.4, W: -3.3}, want: 0.42930619059696984},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fn(tt.p); math.Abs(got-tt.want) > eps {
+				t.Errorf("noise(%v) = %v, want %v", tt.p, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNoise_Seed(t *testing.T) {
+	p := vectozavr.NewVec3(3.7, -1.2, 2.2)
+	if a, b := New(42).Perlin3(p), New(42).Perlin3(p); a != b {
+		t.Errorf("same seed gives %v and %v", a, b)
+	}
+	if a, b := New(42).Perlin3(p), New(7).Perlin3(p); a == b {
+		t.Errorf("different seeds give the same value %v", a)
+	}
+}
+
+func TestNoise_PerlinLattice(t *testing.T) {
+	n := New(1)
+	for x := -3; x <= 3; x++ {
+		for y := -3; y <= 3; y++ {
+			p := vectozavr.NewVec3(float64(x), float64(y), float64(x+y))
+			if got := n.Perlin3(p); got != 0 {
+				t.Errorf("Perlin3(%v) = %v, want 0", p, got)
+			}
+		}
+	}
+}
+
+// Compares the analytic gradient with central differences
+func TestNoise_Gradients(t *testing.T) {
+	n := New(42)
+	const h = 1e-6
+	points := []vectozavr.Vec3{{X: 0.5, Y: 0.25, Z: 0.75}, {X: 3.7, Y: -1.2, Z: 2.2}, {X: -12.3, Y: 8.9, Z: -0.4}}
+	grads3 := map[string]GradFunc3{"perlin": n.Perlin3Grad, "simplex": n.Simplex3Grad, "value": n.Value3Grad}
+	grads2 := map[string]GradFunc2{"perlin": n.Perlin2Grad, "simplex": n.Simplex2Grad, "value": n.Value2Grad}
+	for name, fn := range grads3 {
+		t.Run(name+"3", func(t *testing.T) {
+			for _, p := range points {
+				_, g := fn(p)
+				axes := []vectozavr.Vec3{{X: h}, {Y: h}, {Z: h}}
+				want := []float64{g.X, g.Y, g.Z}
+				for i, d := range axes {
+					a, _ := fn(p.Add(d))
+					b, _ := fn(p.Sub(d))
+					if fd := (a - b) / (2 * h); math.Abs(fd-want[i]) > 1e-6 {
+						t.Errorf("gradient at %v axis %d = %v, finite difference %v", p, i, want[i], fd)
+					}
+				}
+			}
+		})
+	}
+	for name, fn := range grads2 {
+		t.Run(name+"2", func(t *testing.T) {
+			for _, p3 := range points {
+				p := p3.ToVec4().ToVec2()
+				_, g := fn(p)
+				axes := []vectozavr.Vec2{{X: h}, {Y: h}}
+				want := []float64{g.X, g.Y}
+				for i, d := range axes {
+					a, _ := fn(p.Add(d))
+					b, _ := fn(p.Sub(d))
+					if fd := (a - b) / (2 * h); math.Abs(fd-want[i]) > 1e-6 {
+						t.Errorf("gradient at %v axis %d = %v, finite difference %v", p, i, want[i], fd)
+					}
+				}
+			}
+		})
+	}
+}
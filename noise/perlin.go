@@ -0,0 +1,158 @@
+package noise
+
+import (
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+var grad2 = [8]vectozavr.Vec2{
+	{X: 1, Y: 1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: -1},
+	{X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: -1},
+}
+
+// Edge midpoints of a cube, the last four repeated to fill 16 slots
+var grad3 = [16]vectozavr.Vec3{
+	{X: 1, Y: 1, Z: 0}, {X: -1, Y: 1, Z: 0}, {X: 1, Y: -1, Z: 0}, {X: -1, Y: -1, Z: 0},
+	{X: 1, Y: 0, Z: 1}, {X: -1, Y: 0, Z: 1}, {X: 1, Y: 0, Z: -1}, {X: -1, Y: 0, Z: -1},
+	{X: 0, Y: 1, Z: 1}, {X: 0, Y: -1, Z: 1}, {X: 0, Y: 1, Z: -1}, {X: 0, Y: -1, Z: -1},
+	{X: 1, Y: 1, Z: 0}, {X: -1, Y: 1, Z: 0}, {X: 0, Y: -1, Z: 1}, {X: 0, Y: -1, Z: -1},
+}
+
+// Edge midpoints of a tesseract
+var grad4 = [32]vectozavr.Vec4{
+	{X: 0, Y: 1, Z: 1, W: 1}, {X: 0, Y: 1, Z: 1, W: -1}, {X: 0, Y: 1, Z: -1, W: 1}, {X: 0, Y: 1, Z: -1, W: -1},
+	{X: 0, Y: -1, Z: 1, W: 1}, {X: 0, Y: -1, Z: 1, W: -1}, {X: 0, Y: -1, Z: -1, W: 1}, {X: 0, Y: -1, Z: -1, W: -1},
+	{X: 1, Y: 0, Z: 1, W: 1}, {X: 1, Y: 0, Z: 1, W: -1}, {X: 1, Y: 0, Z: -1, W: 1}, {X: 1, Y: 0, Z: -1, W: -1},
+	{X: -1, Y: 0, Z: 1, W: 1}, {X: -1, Y: 0, Z: 1, W: -1}, {X: -1, Y: 0, Z: -1, W: 1}, {X: -1, Y: 0, Z: -1, W: -1},
+	{X: 1, Y: 1, Z: 0, W: 1}, {X: 1, Y: 1, Z: 0, W: -1}, {X: 1, Y: -1, Z: 0, W: 1}, {X: 1, Y: -1, Z: 0, W: -1},
+	{X: -1, Y: 1, Z: 0, W: 1}, {X: -1, Y: 1, Z: 0, W: -1}, {X: -1, Y: -1, Z: 0, W: 1}, {X: -1, Y: -1, Z: 0, W: -1},
+	{X: 1, Y: 1, Z: 1, W: 0}, {X: 1, Y: 1, Z: -1, W: 0}, {X: 1, Y: -1, Z: 1, W: 0}, {X: 1, Y: -1, Z: -1, W: 0},
+	{X: -1, Y: 1, Z: 1, W: 0}, {X: -1, Y: 1, Z: -1, W: 0}, {X: -1, Y: -1, Z: 1, W: 0}, {X: -1, Y: -1, Z: -1, W: 0},
+}
+
+// 2D Perlin gradient noise, roughly in [-1, 1]
+func (n *Noise) Perlin2(p vectozavr.Vec2) float64 {
+	v, _ := n.Perlin2Grad(p)
+	return v
+}
+
+// 2D Perlin noise together with its analytic gradient
+func (n *Noise) Perlin2Grad(p vectozavr.Vec2) (float64, vectozavr.Vec2) {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+
+	g00 := grad2[n.hash2(xi, yi)&7]
+	g10 := grad2[n.hash2(xi+1, yi)&7]
+	g01 := grad2[n.hash2(xi, yi+1)&7]
+	g11 := grad2[n.hash2(xi+1, yi+1)&7]
+
+	n00 := g00.Dot(vectozavr.NewVec2(xf, yf))
+	n10 := g10.Dot(vectozavr.NewVec2(xf-1, yf))
+	n01 := g01.Dot(vectozavr.NewVec2(xf, yf-1))
+	n11 := g11.Dot(vectozavr.NewVec2(xf-1, yf-1))
+
+	u, v := fade(xf), fade(yf)
+	du, dv := dfade(xf), dfade(yf)
+
+	k1 := n10 - n00
+	k2 := n01 - n00
+	k3 := n00 - n10 - n01 + n11
+	value := n00 + u*k1 + v*k2 + u*v*k3
+
+	grad := g00.
+		Add(g10.Sub(g00).Mul(u)).
+		Add(g01.Sub(g00).Mul(v)).
+		Add(g00.Sub(g10).Sub(g01).Add(g11).Mul(u * v)).
+		Add(vectozavr.NewVec2(du*(k1+v*k3), dv*(k2+u*k3)))
+
+	return value, grad
+}
+
+// 3D Perlin gradient noise, roughly in [-1, 1]
+func (n *Noise) Perlin3(p vectozavr.Vec3) float64 {
+	v, _ := n.Perlin3Grad(p)
+	return v
+}
+
+// 3D Perlin noise together with its analytic gradient
+func (n *Noise) Perlin3Grad(p vectozavr.Vec3) (float64, vectozavr.Vec3) {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+	zi, zf := cell(p.Z)
+
+	g := func(dx, dy, dz int) vectozavr.Vec3 {
+		return grad3[n.hash3(xi+dx, yi+dy, zi+dz)&15]
+	}
+	g000, g100, g010, g110 := g(0, 0, 0), g(1, 0, 0), g(0, 1, 0), g(1, 1, 0)
+	g001, g101, g011, g111 := g(0, 0, 1), g(1, 0, 1), g(0, 1, 1), g(1, 1, 1)
+
+	n000 := g000.Dot(vectozavr.NewVec3(xf, yf, zf))
+	n100 := g100.Dot(vectozavr.NewVec3(xf-1, yf, zf))
+	n010 := g010.Dot(vectozavr.NewVec3(xf, yf-1, zf))
+	n110 := g110.Dot(vectozavr.NewVec3(xf-1, yf-1, zf))
+	n001 := g001.Dot(vectozavr.NewVec3(xf, yf, zf-1))
+	n101 := g101.Dot(vectozavr.NewVec3(xf-1, yf, zf-1))
+	n011 := g011.Dot(vectozavr.NewVec3(xf, yf-1, zf-1))
+	n111 := g111.Dot(vectozavr.NewVec3(xf-1, yf-1, zf-1))
+
+	u, v, w := fade(xf), fade(yf), fade(zf)
+	du, dv, dw := dfade(xf), dfade(yf), dfade(zf)
+
+	k0 := n000
+	k1 := n100 - n000
+	k2 := n010 - n000
+	k3 := n001 - n000
+	k4 := n000 - n100 - n010 + n110
+	k5 := n000 - n010 - n001 + n011
+	k6 := n000 - n100 - n001 + n101
+	k7 := -n000 + n100 + n010 - n110 + n001 - n101 - n011 + n111
+
+	value := k0 + u*k1 + v*k2 + w*k3 + u*v*k4 + v*w*k5 + w*u*k6 + u*v*w*k7
+
+	gk0 := g000
+	gk1 := g100.Sub(g000)
+	gk2 := g010.Sub(g000)
+	gk3 := g001.Sub(g000)
+	gk4 := g000.Sub(g100).Sub(g010).Add(g110)
+	gk5 := g000.Sub(g010).Sub(g001).Add(g011)
+	gk6 := g000.Sub(g100).Sub(g001).Add(g101)
+	gk7 := g100.Add(g010).Add(g001).Add(g111).Sub(g000).Sub(g110).Sub(g101).Sub(g011)
+
+	grad := gk0.
+		Add(gk1.Mul(u)).
+		Add(gk2.Mul(v)).
+		Add(gk3.Mul(w)).
+		Add(gk4.Mul(u * v)).
+		Add(gk5.Mul(v * w)).
+		Add(gk6.Mul(w * u)).
+		Add(gk7.Mul(u * v * w)).
+		Add(vectozavr.NewVec3(
+			du*(k1+v*k4+w*k6+v*w*k7),
+			dv*(k2+u*k4+w*k5+u*w*k7),
+			dw*(k3+v*k5+u*k6+u*v*k7),
+		))
+
+	return value, grad
+}
+
+// 4D Perlin gradient noise, roughly in [-1, 1]
+func (n *Noise) Perlin4(p vectozavr.Vec4) float64 {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+	zi, zf := cell(p.Z)
+	wi, wf := cell(p.W)
+
+	corner := func(dx, dy, dz, dw int) float64 {
+		g := grad4[n.hash4(xi+dx, yi+dy, zi+dz, wi+dw)&31]
+		return g.Dot(vectozavr.NewVec4(xf-float64(dx), yf-float64(dy), zf-float64(dz), wf-float64(dw)))
+	}
+	u, v, s, t := fade(xf), fade(yf), fade(zf), fade(wf)
+
+	slice := func(dw int) float64 {
+		x00 := lerp(corner(0, 0, 0, dw), corner(1, 0, 0, dw), u)
+		x10 := lerp(corner(0, 1, 0, dw), corner(1, 1, 0, dw), u)
+		x01 := lerp(corner(0, 0, 1, dw), corner(1, 0, 1, dw), u)
+		x11 := lerp(corner(0, 1, 1, dw), corner(1, 1, 1, dw), u)
+		return lerp(lerp(x00, x10, v), lerp(x01, x11, v), s)
+	}
+	return lerp(slice(0), slice(1), t)
+}
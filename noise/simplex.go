@@ -0,0 +1,188 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Skewing and unskewing factors of the simplex grids
+var (
+	f2 = 0.5 * (math.Sqrt(3) - 1)
+	g2 = (3 - math.Sqrt(3)) / 6
+	f3 = 1.0 / 3.0
+	g3 = 1.0 / 6.0
+	f4 = (math.Sqrt(5) - 1) / 4
+	g4 = (5 - math.Sqrt(5)) / 20
+)
+
+// 2D simplex noise, roughly in [-1, 1]
+func (n *Noise) Simplex2(p vectozavr.Vec2) float64 {
+	v, _ := n.Simplex2Grad(p)
+	return v
+}
+
+// 2D simplex noise together with its analytic gradient
+func (n *Noise) Simplex2Grad(p vectozavr.Vec2) (float64, vectozavr.Vec2) {
+	s := (p.X + p.Y) * f2
+	i := int(math.Floor(p.X + s))
+	j := int(math.Floor(p.Y + s))
+	t := float64(i+j) * g2
+	d0 := vectozavr.NewVec2(p.X-(float64(i)-t), p.Y-(float64(j)-t))
+
+	i1, j1 := 0, 1
+	if d0.X > d0.Y {
+		i1, j1 = 1, 0
+	}
+	d1 := vectozavr.NewVec2(d0.X-float64(i1)+g2, d0.Y-float64(j1)+g2)
+	d2 := vectozavr.NewVec2(d0.X-1+2*g2, d0.Y-1+2*g2)
+
+	var value float64
+	var grad vectozavr.Vec2
+	corner := func(d vectozavr.Vec2, h int) {
+		t := 0.5 - d.Dot(d)
+		if t <= 0 {
+			return
+		}
+		g := grad2[h&7]
+		gd := g.Dot(d)
+		t2 := t * t
+		value += t2 * t2 * gd
+		grad = grad.Add(g.Mul(t2 * t2)).Sub(d.Mul(8 * t2 * t * gd))
+	}
+	corner(d0, n.hash2(i, j))
+	corner(d1, n.hash2(i+i1, j+j1))
+	corner(d2, n.hash2(i+1, j+1))
+
+	return 70 * value, grad.Mul(70)
+}
+
+// 3D simplex noise, roughly in [-1, 1]
+func (n *Noise) Simplex3(p vectozavr.Vec3) float64 {
+	v, _ := n.Simplex3Grad(p)
+	return v
+}
+
+// 3D simplex noise together with its analytic gradient
+func (n *Noise) Simplex3Grad(p vectozavr.Vec3) (float64, vectozavr.Vec3) {
+	s := (p.X + p.Y + p.Z) * f3
+	i := int(math.Floor(p.X + s))
+	j := int(math.Floor(p.Y + s))
+	k := int(math.Floor(p.Z + s))
+	t := float64(i+j+k) * g3
+	d0 := vectozavr.NewVec3(p.X-(float64(i)-t), p.Y-(float64(j)-t), p.Z-(float64(k)-t))
+
+	// Offsets of the second and third corners, chosen by the order of the coordinates
+	var i1, j1, k1, i2, j2, k2 int
+	if d0.X >= d0.Y {
+		switch {
+		case d0.Y >= d0.Z:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 1, 0
+		case d0.X >= d0.Z:
+			i1, j1, k1, i2, j2, k2 = 1, 0, 0, 1, 0, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 1, 0, 1
+		}
+	} else {
+		switch {
+		case d0.Y < d0.Z:
+			i1, j1, k1, i2, j2, k2 = 0, 0, 1, 0, 1, 1
+		case d0.X < d0.Z:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 0, 1, 1
+		default:
+			i1, j1, k1, i2, j2, k2 = 0, 1, 0, 1, 1, 0
+		}
+	}
+	d1 := d0.Sub(vectozavr.NewVec3(float64(i1)-g3, float64(j1)-g3, float64(k1)-g3))
+	d2 := d0.Sub(vectozavr.NewVec3(float64(i2)-2*g3, float64(j2)-2*g3, float64(k2)-2*g3))
+	d3 := d0.Sub(vectozavr.NewVec3(1-3*g3, 1-3*g3, 1-3*g3))
+
+	var value float64
+	var grad vectozavr.Vec3
+	corner := func(d vectozavr.Vec3, h int) {
+		t := 0.6 - d.Dot(d)
+		if t <= 0 {
+			return
+		}
+		g := grad3[h&15]
+		gd := g.Dot(d)
+		t2 := t * t
+		value += t2 * t2 * gd
+		grad = grad.Add(g.Mul(t2 * t2)).Sub(d.Mul(8 * t2 * t * gd))
+	}
+	corner(d0, n.hash3(i, j, k))
+	corner(d1, n.hash3(i+i1, j+j1, k+k1))
+	corner(d2, n.hash3(i+i2, j+j2, k+k2))
+	corner(d3, n.hash3(i+1, j+1, k+1))
+
+	return 32 * value, grad.Mul(32)
+}
+
+// 4D simplex noise, roughly in [-1, 1]
+func (n *Noise) Simplex4(p vectozavr.Vec4) float64 {
+	s := (p.X + p.Y + p.Z + p.W) * f4
+	i := int(math.Floor(p.X + s))
+	j := int(math.Floor(p.Y + s))
+	k := int(math.Floor(p.Z + s))
+	l := int(math.Floor(p.W + s))
+	t := float64(i+j+k+l) * g4
+	d0 := vectozavr.NewVec4(p.X-(float64(i)-t), p.Y-(float64(j)-t), p.Z-(float64(k)-t), p.W-(float64(l)-t))
+
+	// Rank the coordinates to find which simplex of the hypercube we are in
+	var rx, ry, rz, rw int
+	if d0.X > d0.Y {
+		rx++
+	} else {
+		ry++
+	}
+	if d0.X > d0.Z {
+		rx++
+	} else {
+		rz++
+	}
+	if d0.X > d0.W {
+		rx++
+	} else {
+		rw++
+	}
+	if d0.Y > d0.Z {
+		ry++
+	} else {
+		rz++
+	}
+	if d0.Y > d0.W {
+		ry++
+	} else {
+		rw++
+	}
+	if d0.Z > d0.W {
+		rz++
+	} else {
+		rw++
+	}
+	step := func(rank, threshold int) int {
+		if rank >= threshold {
+			return 1
+		}
+		return 0
+	}
+
+	var value float64
+	corner := func(c int) {
+		oi, oj, ok, ol := step(rx, 4-c), step(ry, 4-c), step(rz, 4-c), step(rw, 4-c)
+		off := float64(c) * g4
+		d := d0.Sub(vectozavr.NewVec4(float64(oi)-off, float64(oj)-off, float64(ok)-off, float64(ol)-off))
+		t := 0.6 - d.Dot(d)
+		if t <= 0 {
+			return
+		}
+		g := grad4[n.hash4(i+oi, j+oj, k+ok, l+ol)&31]
+		t2 := t * t
+		value += t2 * t2 * g.Dot(d)
+	}
+	for c := 0; c <= 4; c++ {
+		corner(c)
+	}
+
+	return 27 * value
+}
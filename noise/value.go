@@ -0,0 +1,99 @@
+package noise
+
+import (
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Maps a lattice hash to a value in [-1, 1]
+func lattice(h int) float64 {
+	return float64(h)/127.5 - 1
+}
+
+// 2D value noise in [-1, 1]
+func (n *Noise) Value2(p vectozavr.Vec2) float64 {
+	v, _ := n.Value2Grad(p)
+	return v
+}
+
+// 2D value noise together with its analytic gradient
+func (n *Noise) Value2Grad(p vectozavr.Vec2) (float64, vectozavr.Vec2) {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+
+	a := lattice(n.hash2(xi, yi))
+	b := lattice(n.hash2(xi+1, yi))
+	c := lattice(n.hash2(xi, yi+1))
+	d := lattice(n.hash2(xi+1, yi+1))
+
+	u, v := fade(xf), fade(yf)
+	du, dv := dfade(xf), dfade(yf)
+
+	k1 := b - a
+	k2 := c - a
+	k3 := a - b - c + d
+
+	value := a + u*k1 + v*k2 + u*v*k3
+	grad := vectozavr.NewVec2(du*(k1+v*k3), dv*(k2+u*k3))
+	return value, grad
+}
+
+// 3D value noise in [-1, 1]
+func (n *Noise) Value3(p vectozavr.Vec3) float64 {
+	v, _ := n.Value3Grad(p)
+	return v
+}
+
+// 3D value noise together with its analytic gradient
+func (n *Noise) Value3Grad(p vectozavr.Vec3) (float64, vectozavr.Vec3) {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+	zi, zf := cell(p.Z)
+
+	c := func(dx, dy, dz int) float64 {
+		return lattice(n.hash3(xi+dx, yi+dy, zi+dz))
+	}
+	n000, n100, n010, n110 := c(0, 0, 0), c(1, 0, 0), c(0, 1, 0), c(1, 1, 0)
+	n001, n101, n011, n111 := c(0, 0, 1), c(1, 0, 1), c(0, 1, 1), c(1, 1, 1)
+
+	u, v, w := fade(xf), fade(yf), fade(zf)
+	du, dv, dw := dfade(xf), dfade(yf), dfade(zf)
+
+	k0 := n000
+	k1 := n100 - n000
+	k2 := n010 - n000
+	k3 := n001 - n000
+	k4 := n000 - n100 - n010 + n110
+	k5 := n000 - n010 - n001 + n011
+	k6 := n000 - n100 - n001 + n101
+	k7 := -n000 + n100 + n010 - n110 + n001 - n101 - n011 + n111
+
+	value := k0 + u*k1 + v*k2 + w*k3 + u*v*k4 + v*w*k5 + w*u*k6 + u*v*w*k7
+	grad := vectozavr.NewVec3(
+		du*(k1+v*k4+w*k6+v*w*k7),
+		dv*(k2+u*k4+w*k5+u*w*k7),
+		dw*(k3+v*k5+u*k6+u*v*k7),
+	)
+	return value, grad
+}
+
+// 4D value noise in [-1, 1]
+func (n *Noise) Value4(p vectozavr.Vec4) float64 {
+	xi, xf := cell(p.X)
+	yi, yf := cell(p.Y)
+	zi, zf := cell(p.Z)
+	wi, wf := cell(p.W)
+
+	c := func(dx, dy, dz, dw int) float64 {
+		return lattice(n.hash4(xi+dx, yi+dy, zi+dz, wi+dw))
+	}
+	u, v, s, t := fade(xf), fade(yf), fade(zf), fade(wf)
+
+	slice := func(dw int) float64 {
+		x00 := lerp(c(0, 0, 0, dw), c(1, 0, 0, dw), u)
+		x10 := lerp(c(0, 1, 0, dw), c(1, 1, 0, dw), u)
+		x01 := lerp(c(0, 0, 1, dw), c(1, 0, 1, dw), u)
+		x11 := lerp(c(0, 1, 1, dw), c(1, 1, 1, dw), u)
+		return lerp(lerp(x00, x10, v), lerp(x01, x11, v), s)
+	}
+	return lerp(slice(0), slice(1), t)
+}
@@ -1,10 +1,15 @@
 package object
 
 import (
+	"github.com/rudolfkova/vectozavr/mesh"
 	"github.com/rudolfkova/vectozavr/vectozavr"
 )
 
 type Object struct {
+	Name string
+	// Geometry drawn through the object's transform; nil for none
+	Mesh *mesh.Mesh
+
 	position          vectozavr.Vec3
 	TransformMatrix   vectozavr.Matrix
 	angle             vectozavr.Vec3
@@ -12,6 +17,13 @@ type Object struct {
 	left              vectozavr.Vec3
 	up                vectozavr.Vec3
 	lookAt            vectozavr.Vec3
+
+	// Scene graph; the transform is relative to the parent
+	parent   *Object
+	children []*Object
+	// Cached transform to the world, valid until the object or an ancestor changes
+	world      vectozavr.Matrix
+	worldValid bool
 }
 
 func NewObject(m vectozavr.Matrix) *Object {
@@ -35,6 +47,7 @@ func (o *Object) GetPos() vectozavr.Vec3 {
 
 func (o *Object) Transform(t vectozavr.Matrix) {
 	o.TransformMatrix = o.TransformMatrix.MatMul(t)
+	o.Invalidate()
 }
 
 func (o *Object) Left() {
@@ -57,11 +70,12 @@ func (o *Object) TransformRelativePoint(point vectozavr.Vec3, transform vectozav
 	// translate object back in self connected coordinate system
 	o.position = o.TransformMatrix.W().Add(point)
 	o.TransformMatrix = vectozavr.Translation(o.TransformMatrix.W()).MatMul(o.TransformMatrix)
-
+	o.Invalidate()
 }
 
 func (o *Object) Translate(v vectozavr.Vec3) {
 	o.position = o.position.Add(v)
+	o.Invalidate()
 }
 
 func (o *Object) Scale(s vectozavr.Vec3) {
@@ -73,7 +87,7 @@ func (o *Object) Rotate(a vectozavr.Vec3) {
 	o.Transform(vectozavr.Rotation(a))
 }
 
-func (o *Object) VRotate(v vectozavr.Vec3, a float64) {
+func (o *Object) VRotate(v vectozavr.Vec3, a vectozavr.Radians) {
 	o.Transform(vectozavr.RotationV(v, a))
 }
 
@@ -82,21 +96,36 @@ func (o *Object) RotateRelativePoint(s vectozavr.Vec3, r vectozavr.Vec3) {
 	o.TransformRelativePoint(s, vectozavr.Rotation(r))
 }
 
-func (o *Object) RotateLeft(rl float64) {
-	o.angleLeftUpLookAt.X += rl
+func (o *Object) RotateLeft(rl vectozavr.Radians) {
+	o.angleLeftUpLookAt.X += float64(rl)
 	o.VRotate(o.left, rl)
 }
 
-func (o *Object) RotateUp(rl float64) {
-	o.angleLeftUpLookAt.Y += rl
+func (o *Object) RotateUp(rl vectozavr.Radians) {
+	o.angleLeftUpLookAt.Y += float64(rl)
 	o.VRotate(o.up, rl)
 }
 
-func (o *Object) RotateLookAt(rl float64) {
-	o.angleLeftUpLookAt.Z += rl
+func (o *Object) RotateLookAt(rl vectozavr.Radians) {
+	o.angleLeftUpLookAt.Z += float64(rl)
 	o.VRotate(o.lookAt, rl)
 }
 
 func (o *Object) TranslateToPoint(point vectozavr.Vec3) {
 	o.Translate(point.Sub(o.position))
 }
+
+// Returns the world box around the object's mesh after its transform.
+// An object without a mesh takes up the unit cube [-1, 1]³.
+func (o *Object) Bounds() vectozavr.AABB {
+	box := vectozavr.NewAABB()
+	local := vectozavr.NewAABB(vectozavr.NewVec3(-1, -1, -1), vectozavr.NewVec3(1, 1, 1))
+	if o.Mesh != nil && len(o.Mesh.Positions) > 0 {
+		local = o.Mesh.Bounds()
+	}
+	world := o.World()
+	for _, p := range local.Corners() {
+		box = box.Extend(world.Vec4Mul(p.ToVec4()).ToVec3())
+	}
+	return box
+}
@@ -0,0 +1,160 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Separates the names in a path of objects
+const PathSeparator = "/"
+
+// Returns the object's transform relative to its parent: the
+// TransformMatrix followed by the translation to the position
+func (o *Object) Local() vectozavr.Matrix {
+	return vectozavr.Translation(o.position).MatMul(o.TransformMatrix)
+}
+
+// Returns the transform from the object's coordinates to the world,
+// rebuilding it if the object or one of its ancestors changed
+func (o *Object) World() vectozavr.Matrix {
+	if !o.worldValid {
+		o.world = o.Local()
+		if o.parent != nil {
+			o.world = o.parent.World().MatMul(o.world)
+		}
+		o.worldValid = true
+	}
+	return o.world
+}
+
+// The origin of the object in world coordinates
+func (o *Object) WorldPos() vectozavr.Vec3 {
+	return o.World().W()
+}
+
+// Replaces the transform matrix
+func (o *Object) SetTransform(m vectozavr.Matrix) {
+	o.TransformMatrix = m
+	o.Invalidate()
+}
+
+// Marks the world transforms of the object and its descendants as out of
+// date. Methods changing the object do it themselves; call it after
+// writing TransformMatrix directly.
+func (o *Object) Invalidate() {
+	if !o.worldValid {
+		// The descendants were invalidated together with the object
+		return
+	}
+	o.worldValid = false
+	for _, c := range o.children {
+		c.Invalidate()
+	}
+}
+
+func (o *Object) Parent() *Object {
+	return o.parent
+}
+
+func (o *Object) Children() []*Object {
+	return o.children
+}
+
+// Attaches the child to the object, see SetParent
+func (o *Object) AddChild(c *Object) error {
+	return c.SetParent(o)
+}
+
+// Moves the object under a new parent, or makes it a root on nil. The
+// object keeps its place in the world: its local transform is recomputed
+// relative to the new parent.
+func (o *Object) SetParent(p *Object) error {
+	if p == o.parent {
+		return nil
+	}
+	for a := p; a != nil; a = a.parent {
+		if a == o {
+			return fmt.Errorf("cannot attach %q to its own descendant %q", o.Name, p.Name)
+		}
+	}
+	local := o.World()
+	if p != nil {
+		inv, err := p.World().Inverse()
+		if err != nil {
+			return fmt.Errorf("cannot attach %q to %q: %v", o.Name, p.Name, err)
+		}
+		local = inv.MatMul(local)
+	}
+
+	if o.parent != nil {
+		siblings := o.parent.children
+		for i, c := range siblings {
+			if c == o {
+				o.parent.children = append(siblings[:i:i], siblings[i+1:]...)
+				break
+			}
+		}
+	}
+	o.parent = p
+	if p != nil {
+		p.children = append(p.children, o)
+	}
+
+	// Split the local transform back into the position and the matrix
+	o.position = local.W()
+	o.TransformMatrix = vectozavr.Translation(o.position.Mul(-1)).MatMul(local)
+	o.Invalidate()
+	return nil
+}
+
+// Visits the object and its descendants depth first, parents before
+// children. Returning false from fn skips the children of that object.
+func (o *Object) Walk(fn func(o *Object, depth int) bool) {
+	o.walk(fn, 0)
+}
+
+func (o *Object) walk(fn func(o *Object, depth int) bool, depth int) {
+	if !fn(o, depth) {
+		return
+	}
+	for _, c := range o.children {
+		c.walk(fn, depth+1)
+	}
+}
+
+// Finds a descendant by the names on the way down from the object,
+// separated by slashes, such as "arm/hand". Returns nil if there is none.
+func (o *Object) Find(path string) *Object {
+	cur := o
+	for _, name := range strings.Split(path, PathSeparator) {
+		if name == "" {
+			continue
+		}
+		var next *Object
+		for _, c := range cur.children {
+			if c.Name == name {
+				next = c
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		cur = next
+	}
+	return cur
+}
+
+// The names from the root down to the object, separated by slashes
+func (o *Object) Path() string {
+	var names []string
+	for a := o; a != nil; a = a.parent {
+		names = append(names, a.Name)
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, PathSeparator)
+}
@@ -0,0 +1,111 @@
+package object
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func nearVec3(a, b vectozavr.Vec3) bool {
+	const eps = 1e-9
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps && math.Abs(a.Z-b.Z) < eps
+}
+
+// A chain body → arm → hand, each one unit along the X axis of its parent
+func testChain(t *testing.T) (body, arm, hand *Object) {
+	body = NewObject(vectozavr.Identity())
+	body.Name = "body"
+	arm = NewObject(vectozavr.Identity())
+	arm.Name = "arm"
+	hand = NewObject(vectozavr.Identity())
+	hand.Name = "hand"
+	if err := body.AddChild(arm); err != nil {
+		t.Fatal(err)
+	}
+	if err := arm.AddChild(hand); err != nil {
+		t.Fatal(err)
+	}
+	arm.Translate(vectozavr.NewVec3(1, 0, 0))
+	hand.Translate(vectozavr.NewVec3(1, 0, 0))
+	return body, arm, hand
+}
+
+func TestObject_World(t *testing.T) {
+	body, arm, hand := testChain(t)
+	if got, want := hand.WorldPos(), vectozavr.NewVec3(2, 0, 0); !nearVec3(got, want) {
+		t.Errorf("hand at %v, want %v", got, want)
+	}
+
+	// Turning the arm a quarter around Z swings the hand, moving the body carries both
+	arm.VRotate(vectozavr.NewVec3(0, 0, 1), math.Pi/2)
+	body.Translate(vectozavr.NewVec3(0, 0, 3))
+	if got, want := hand.WorldPos(), vectozavr.NewVec3(1, 1, 3); !nearVec3(got, want) {
+		t.Errorf("hand at %v, want %v", got, want)
+	}
+	if got, want := hand.Bounds().Center(), vectozavr.NewVec3(1, 1, 3); !nearVec3(got, want) {
+		t.Errorf("hand bounds around %v, want %v", got, want)
+	}
+
+	// Writing the matrix directly needs an explicit invalidation
+	arm.TransformMatrix = vectozavr.Identity()
+	arm.Invalidate()
+	if got, want := hand.WorldPos(), vectozavr.NewVec3(2, 0, 3); !nearVec3(got, want) {
+		t.Errorf("hand at %v, want %v", got, want)
+	}
+}
+
+func TestObject_SetParentKeepsWorld(t *testing.T) {
+	body, arm, hand := testChain(t)
+	arm.VRotate(vectozavr.NewVec3(0, 0, 1), math.Pi/2)
+	other := NewObject(vectozavr.Scale(vectozavr.NewVec3(2, 2, 2)))
+	other.Translate(vectozavr.NewVec3(-3, 1, 0))
+
+	before := hand.World()
+	if err := hand.SetParent(other); err != nil {
+		t.Fatal(err)
+	}
+	after := hand.World()
+	for _, p := range []vectozavr.Vec3{vectozavr.ZeroVec3(), vectozavr.NewVec3(1, 2, 3)} {
+		if a, b := before.Vec4Mul(p.ToVec4()).ToVec3(), after.Vec4Mul(p.ToVec4()).ToVec3(); !nearVec3(a, b) {
+			t.Errorf("%v moved from %v to %v", p, a, b)
+		}
+	}
+	if len(arm.Children()) != 0 || hand.Parent() != other {
+		t.Errorf("hand is still attached to the arm")
+	}
+	if err := body.SetParent(arm); err == nil {
+		t.Errorf("attaching the body to its descendant succeeded")
+	}
+	if err := hand.SetParent(nil); err != nil || !nearVec3(hand.GetPos(), after.W()) {
+		t.Errorf("detached hand at %v, want %v (%v)", hand.GetPos(), after.W(), err)
+	}
+}
+
+func TestObject_WalkAndFind(t *testing.T) {
+	body, arm, hand := testChain(t)
+	leg := NewObject(vectozavr.Identity())
+	leg.Name = "leg"
+	if err := body.AddChild(leg); err != nil {
+		t.Fatal(err)
+	}
+
+	var visited []string
+	body.Walk(func(o *Object, depth int) bool {
+		visited = append(visited, o.Name)
+		return o != arm || depth != 1
+	})
+	if got, want := len(visited), 3; got != want || visited[1] != "arm" || visited[2] != "leg" {
+		t.Errorf("visited %v, want [body arm leg]", visited)
+	}
+
+	if got := body.Find("arm/hand"); got != hand {
+		t.Errorf("Find(arm/hand) = %v", got)
+	}
+	if got := body.Find("arm/foot"); got != nil {
+		t.Errorf("Find(arm/foot) = %v, want nil", got)
+	}
+	if got := hand.Path(); got != "body/arm/hand" {
+		t.Errorf("Path() = %q", got)
+	}
+}
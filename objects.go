@@ -0,0 +1,191 @@
+package main
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rudolfkova/vectozavr/camera"
+	"github.com/rudolfkova/vectozavr/mesh"
+	"github.com/rudolfkova/vectozavr/object"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Distance of the primitives from the origin
+const primitivesRadius = 7
+
+// The axes gizmo at the origin
+func newGizmo(conv vectozavr.Conventions) *object.Object {
+	o := object.NewObject(modelFrame(conv))
+	o.Name, o.Mesh = "axes", mesh.Axes(1)
+	return o
+}
+
+// The generated primitives standing on the ground in a circle around the
+// origin
+func newPrimitives(conv vectozavr.Conventions) []*object.Object {
+	meshes := []struct {
+		name string
+		m    *mesh.Mesh
+	}{
+		{"box", mesh.Box(vectozavr.NewVec3(1.5, 1, 1))},
+		{"plane", mesh.Plane(2, 2, 4, 4)},
+		{"sphere", mesh.UVSphere(0.8, 16, 8)},
+		{"icosphere", mesh.Icosphere(0.8, 1)},
+		{"cylinder", mesh.Cylinder(0.6, 1.5, 16, 2)},
+		{"cone", mesh.Cone(0.7, 1.5, 16)},
+		{"torus", mesh.Torus(0.7, 0.25, 24, 8)},
+		{"capsule", mesh.Capsule(0.4, 1, 16, 4)},
+		{"arrow", mesh.Arrow(1.5, 0.08, 0.4, 0.2, 12)},
+	}
+	side, up, fwd := conv.SideVector(), conv.UpVector(), conv.ForwardVector()
+	frame := modelFrame(conv)
+
+	var objects []*object.Object
+	for i, p := range meshes {
+		o := object.NewObject(frame)
+		o.Name, o.Mesh = p.name, p.m
+		a := 2 * math.Pi * float64(i) / float64(len(meshes))
+		// Resting on the ground
+		lift := -p.m.Bounds().Min.Y
+		o.Translate(side.Mul(primitivesRadius * math.Cos(a)).Add(fwd.Mul(primitivesRadius * math.Sin(a))).Add(up.Mul(lift)))
+		objects = append(objects, o)
+	}
+	return objects
+}
+
+// Bounding boxes of the solid objects for the camera constraints
+func (g *Game) solidColliders() []camera.Collider {
+	var cols []camera.Collider
+	for _, o := range g.solids {
+		cols = append(cols, camera.ObjectCollider{Object: o})
+	}
+	return cols
+}
+
+// Turns meshes made with Y up and Z forward, as the generators and most
+// model files make them, into the conventions
+func modelFrame(conv vectozavr.Conventions) vectozavr.Matrix {
+	return vectozavr.NewMatrixVec3(conv.SideVector(), conv.UpVector(), conv.ForwardVector())
+}
+
+// Colour of wireframes without vertex colours
+var wireColor = color.RGBA{200, 200, 200, 255}
+
+// Local axis colours of objects: X red, Y green, Z blue
+var objectAxisColors = [3]color.Color{
+	color.RGBA{255, 80, 80, 255},
+	color.RGBA{80, 255, 80, 255},
+	color.RGBA{80, 80, 255, 255},
+}
+
+// Draws the object's mesh in wireframe, or its unit cube when it has
+// none, and its axes through the world transform. A mesh of bare
+// vertices is drawn as a point set.
+func (g *Game) drawObject(screen *ebiten.Image, cam *camera.Camera, o *object.Object) {
+	world := o.World()
+	switch {
+	case o.Mesh != nil && len(o.Mesh.Triangles) == 0 && len(o.Mesh.Edges) == 0:
+		g.drawPoints(screen, cam, o, world)
+		return
+	case o.Mesh != nil:
+		g.drawMesh(screen, cam, o, world)
+	default:
+		g.drawUnitCube(screen, cam, world)
+	}
+	pos := world.W()
+	for i, axis := range []vectozavr.Vec3{world.X(), world.Y(), world.Z()} {
+		drawLine(screen, cam, pos, pos.Add(axis.Mul(2)), objectAxisColors[i])
+	}
+}
+
+// Draws the part of the segment in front of the camera's near plane,
+// without the coordinate labels of ProjLine
+func drawLine(screen *ebiten.Image, cam *camera.Camera, a, b vectozavr.Vec3, clr color.Color) {
+	a, b, ok := cam.ClipNear(a, b)
+	if !ok {
+		return
+	}
+	pa, pb := cam.Project(a), cam.Project(b)
+	vector.StrokeLine(screen, float32(pa.X), float32(pa.Y), float32(pb.X), float32(pb.Y), 1, clr, false)
+}
+
+// Draws the wire edges of the object's mesh, each in the colour of its
+// first vertex
+func (g *Game) drawMesh(screen *ebiten.Image, cam *camera.Camera, o *object.Object, world vectozavr.Matrix) {
+	m := o.Mesh
+	points := make([]vectozavr.Vec3, len(m.Positions))
+	for i, p := range m.Positions {
+		points[i] = world.Vec4Mul(p.ToVec4()).ToVec3()
+	}
+	for _, e := range m.WireEdges() {
+		var clr color.Color = wireColor
+		if len(m.Colors) == len(m.Positions) {
+			clr = m.Colors[e[0]]
+		}
+		drawLine(screen, cam, points[e[0]], points[e[1]], clr)
+	}
+}
+
+// Radius of the markers of point sets, smaller than the clicked points
+const pointSetRadius = 3
+
+// Draws the vertices of the object's mesh as dots in their colours
+func (g *Game) drawPoints(screen *ebiten.Image, cam *camera.Camera, o *object.Object, world vectozavr.Matrix) {
+	m := o.Mesh
+	for i, p := range m.Positions {
+		s, ok := cam.WorldToScreen(world.Vec4Mul(p.ToVec4()).ToVec3(), cam.Viewport)
+		if !ok {
+			continue
+		}
+		var clr color.Color = wireColor
+		if len(m.Colors) == len(m.Positions) {
+			clr = m.Colors[i]
+		}
+		vector.DrawFilledCircle(screen, float32(s.X), float32(s.Y), pointSetRadius, clr, false)
+	}
+}
+
+// Draws the cube [-1, 1]³ through the transform
+func (g *Game) drawUnitCube(screen *ebiten.Image, cam *camera.Camera, world vectozavr.Matrix) {
+	unit := vectozavr.NewAABB(vectozavr.NewVec3(-1, -1, -1), vectozavr.NewVec3(1, 1, 1))
+	corners := unit.Corners()
+	for i, p := range corners {
+		corners[i] = world.Vec4Mul(p.ToVec4()).ToVec3()
+	}
+	// Edges join the corners that differ in one bit
+	for i := 0; i < 8; i++ {
+		for bit := 1; bit < 8; bit <<= 1 {
+			if i&bit == 0 {
+				drawLine(screen, cam, corners[i], corners[i|bit], wireColor)
+			}
+		}
+	}
+}
+
+// The object followed by the perspective camera, or else the object whose
+// origin is nearest to the cursor
+func (g *Game) focusObject() *object.Object {
+	if cam := g.persp.cam; cam.Mode == camera.ModeFollow && cam.Follow.Target != nil {
+		return cam.Follow.Target
+	}
+	const pickRadius = 24
+	best := pickRadius*pickRadius + 1.0
+	var picked *object.Object
+	candidates := append([]*object.Object{}, g.objects...)
+	for _, m := range g.movers {
+		candidates = append(candidates, m.obj)
+	}
+	for _, o := range candidates {
+		s, ok := g.cam.WorldToScreen(o.WorldPos(), g.cam.Viewport)
+		if !ok {
+			continue
+		}
+		dx, dy := s.X-g.cursor.X, s.Y-g.cursor.Y
+		if d := dx*dx + dy*dy; d < best {
+			best, picked = d, o
+		}
+	}
+	return picked
+}
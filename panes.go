@@ -0,0 +1,193 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/rudolfkova/vectozavr/camera"
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// A part of the window showing the scene through its own camera. Clicks
+// add points on the pane's grid plane.
+type pane struct {
+	name string
+	cam  *camera.Camera
+	// Index of the world axis normal to the grid plane: 0 for YZ, 1 for XZ, 2 for XY
+	axis int
+	// Orthographic panes keep their direction: rotation keys and drags are ignored
+	fixed bool
+}
+
+// The grid plane through the origin
+func (p *pane) plane() vectozavr.Plane {
+	return vectozavr.NewPlane(axisVector(p.axis), vectozavr.ZeroVec3())
+}
+
+// Distance of the orthographic cameras from the origin
+const orthoDistance = 20
+
+// Creates an orthographic pane looking at the origin from the standard view.
+// Its grid plane is the one the view looks straight at.
+func newOrthoPane(name string, conv vectozavr.Conventions, view camera.View) *pane {
+	cam := camera.NewCamera(conv, camera.Viewport{})
+	cam.Orthographic = true
+	cam.OrthoHeight = 12
+	cam.MaxPitch = math.Pi / 2
+	cam.Far = 2 * orthoDistance
+	cam.SetMode(camera.ModeOrbit)
+	cam.Orbit.Target = vectozavr.ZeroVec3()
+	cam.Orbit.Distance = orthoDistance
+	cam.ShowView(view, 0)
+	cam.Update()
+	return &pane{name: name, cam: cam, axis: dominantAxis(cam.At), fixed: true}
+}
+
+// Top, front and side orthographic panes and the perspective one, whose
+// grid is the ground plane
+func (g *Game) newPanes() {
+	g.persp = &pane{name: "perspective", cam: g.cam, axis: dominantAxis(g.conv.UpVector())}
+	g.panes = []*pane{
+		newOrthoPane("top", g.conv, camera.ViewTop),
+		newOrthoPane("front", g.conv, camera.ViewFront),
+		newOrthoPane("side", g.conv, camera.ViewRight),
+		g.persp,
+	}
+	g.active = g.persp
+	g.layoutPanes()
+}
+
+// Places the panes in a 2×2 grid, or gives the whole window to the maximized one
+func (g *Game) layoutPanes() {
+	if g.maximized != nil {
+		for _, p := range g.panes {
+			p.cam.SetViewport(camera.Viewport{})
+		}
+		g.maximized.cam.SetViewport(camera.Viewport{Width: g.w, Height: g.h})
+		return
+	}
+	w0, h0 := g.w/2, g.h/2
+	for i, p := range g.panes {
+		vp := camera.Viewport{Width: w0, Height: h0}
+		if i%2 == 1 {
+			vp.X, vp.Width = w0, g.w-w0
+		}
+		if i >= 2 {
+			vp.Y, vp.Height = h0, g.h-h0
+		}
+		p.cam.SetViewport(vp)
+	}
+}
+
+// Maximizes the active pane or returns to the four-pane layout
+func (g *Game) toggleMaximized() {
+	if g.maximized != nil {
+		g.maximized = nil
+	} else {
+		g.maximized = g.active
+	}
+	g.layoutPanes()
+}
+
+// The visible pane under the pixel, or nil
+func (g *Game) paneAt(x, y int) *pane {
+	for _, p := range g.panes {
+		if p.cam.Viewport.Contains(x, y) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Adds a point where the ray under the cursor crosses the pane's grid plane
+func (g *Game) addPoint(p *pane, cursor vectozavr.Vec2) {
+	hit, ok := p.plane().Intersect(p.cam.RayFromScreen(cursor.X, cursor.Y, p.cam.Viewport))
+	if !ok {
+		return
+	}
+	points := g.points(p.axis)
+	*points = append(*points, hit)
+}
+
+// The clicked points lying on the plane normal to the axis
+func (g *Game) points(axis int) *[]vectozavr.Vec3 {
+	switch axis {
+	case 0:
+		return &g.pointYZ
+	case 1:
+		return &g.pointXZ
+	}
+	return &g.pointXY
+}
+
+var (
+	paneBorder       = color.RGBA{80, 80, 80, 255}
+	activePaneBorder = color.RGBA{255, 255, 0, 255}
+)
+
+// Draws the scene through the pane's camera, clipped to its viewport
+func (g *Game) drawPane(screen *ebiten.Image, p *pane) {
+	vp := p.cam.Viewport
+	if vp.Width == 0 || vp.Height == 0 {
+		return
+	}
+	// A sub-image keeps the coordinates of the screen, as the viewport does
+	sub := screen.SubImage(image.Rect(vp.X, vp.Y, vp.X+vp.Width, vp.Y+vp.Height)).(*ebiten.Image)
+
+	if p == g.persp && g.stereoMode != stereoOff {
+		g.drawStereo(sub, p)
+	} else {
+		g.drawScene(sub, p.cam, p.axis)
+	}
+	g.drawViewCube(sub, p.cam, vectozavr.NewVec2(float64(vp.X+vp.Width)-70, float64(vp.Y)+70), 30)
+
+	border := paneBorder
+	if p == g.active {
+		border = activePaneBorder
+	}
+	vector.StrokeRect(sub, float32(vp.X)+0.5, float32(vp.Y)+0.5, float32(vp.Width)-1, float32(vp.Height)-1, 1, border, false)
+	ebitenutil.DebugPrintAt(sub, p.name, vp.X+4, vp.Y+vp.Height-20)
+}
+
+// Draws the grid on the plane normal to the axis, the clicked points and the objects
+func (g *Game) drawScene(screen *ebiten.Image, cam *camera.Camera, axis int) {
+	g.DrawGrid(screen, cam, axis, 0.5, 10)
+	for a, clr := range gridColors {
+		for _, pt := range *g.points(a) {
+			g.DrawProjPoint(screen, cam, pt, clr)
+		}
+	}
+	for _, m := range g.movers {
+		g.drawMover(screen, cam, m)
+	}
+	for _, o := range g.objects {
+		g.drawObject(screen, cam, o)
+	}
+}
+
+// The unit vector along a world axis
+func axisVector(axis int) vectozavr.Vec3 {
+	switch axis {
+	case 0:
+		return vectozavr.NewVec3(1, 0, 0)
+	case 1:
+		return vectozavr.NewVec3(0, 1, 0)
+	}
+	return vectozavr.NewVec3(0, 0, 1)
+}
+
+// Index of the largest component of the vector by absolute value
+func dominantAxis(v vectozavr.Vec3) int {
+	x, y, z := math.Abs(v.X), math.Abs(v.Y), math.Abs(v.Z)
+	switch {
+	case x >= y && x >= z:
+		return 0
+	case y >= z:
+		return 1
+	}
+	return 2
+}
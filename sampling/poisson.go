@@ -0,0 +1,167 @@
+package sampling
+
+import (
+	"math"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Poisson-disk samples in the rectangle [min, max]: no two points are closer
+// than radius. k is the number of candidates tried around each active point
+// (Bridson's algorithm, 30 is a common choice).
+func (s *Sampler) PoissonDisk2(min, max vectozavr.Vec2, radius float64, k int) []vectozavr.Vec2 {
+	size := max.Sub(min)
+	if radius <= 0 || size.X <= 0 || size.Y <= 0 {
+		return nil
+	}
+	cellSize := radius / math.Sqrt2
+	w := int(math.Ceil(size.X / cellSize))
+	h := int(math.Ceil(size.Y / cellSize))
+	grid := make([]int, w*h)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p vectozavr.Vec2) (int, int) {
+		x := int((p.X - min.X) / cellSize)
+		y := int((p.Y - min.Y) / cellSize)
+		return clampInt(x, w), clampInt(y, h)
+	}
+
+	var points []vectozavr.Vec2
+	var active []int
+	add := func(p vectozavr.Vec2) {
+		x, y := cellOf(p)
+		grid[y*w+x] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+	}
+	fits := func(p vectozavr.Vec2) bool {
+		cx, cy := cellOf(p)
+		for y := cy - 2; y <= cy+2; y++ {
+			for x := cx - 2; x <= cx+2; x++ {
+				if x < 0 || y < 0 || x >= w || y >= h {
+					continue
+				}
+				if i := grid[y*w+x]; i >= 0 {
+					d := points[i].Sub(p)
+					if d.Dot(d) < radius*radius {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	add(min.Add(vectozavr.NewVec2(s.r.Float64()*size.X, s.r.Float64()*size.Y)))
+	for len(active) > 0 {
+		i := s.r.IntN(len(active))
+		p := points[active[i]]
+		found := false
+		for j := 0; j < k; j++ {
+			// Uniform by area in the annulus [radius, 2*radius]
+			d := radius * math.Sqrt(1+3*s.r.Float64())
+			a := 2 * math.Pi * s.r.Float64()
+			q := p.Add(vectozavr.NewVec2(d*math.Cos(a), d*math.Sin(a)))
+			if q.X < min.X || q.Y < min.Y || q.X > max.X || q.Y > max.Y {
+				continue
+			}
+			if fits(q) {
+				add(q)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[i] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+// Poisson-disk samples inside the box: no two points are closer than radius.
+// k is the number of candidates tried around each active point.
+func (s *Sampler) PoissonDisk3(box vectozavr.AABB, radius float64, k int) []vectozavr.Vec3 {
+	size := box.Size()
+	if radius <= 0 || size.X <= 0 || size.Y <= 0 || size.Z <= 0 {
+		return nil
+	}
+	cellSize := radius / math.Sqrt(3)
+	w := int(math.Ceil(size.X / cellSize))
+	h := int(math.Ceil(size.Y / cellSize))
+	l := int(math.Ceil(size.Z / cellSize))
+	grid := make([]int, w*h*l)
+	for i := range grid {
+		grid[i] = -1
+	}
+	cellOf := func(p vectozavr.Vec3) (int, int, int) {
+		x := int((p.X - box.Min.X) / cellSize)
+		y := int((p.Y - box.Min.Y) / cellSize)
+		z := int((p.Z - box.Min.Z) / cellSize)
+		return clampInt(x, w), clampInt(y, h), clampInt(z, l)
+	}
+
+	var points []vectozavr.Vec3
+	var active []int
+	add := func(p vectozavr.Vec3) {
+		x, y, z := cellOf(p)
+		grid[(z*h+y)*w+x] = len(points)
+		active = append(active, len(points))
+		points = append(points, p)
+	}
+	fits := func(p vectozavr.Vec3) bool {
+		cx, cy, cz := cellOf(p)
+		for z := cz - 2; z <= cz+2; z++ {
+			for y := cy - 2; y <= cy+2; y++ {
+				for x := cx - 2; x <= cx+2; x++ {
+					if x < 0 || y < 0 || z < 0 || x >= w || y >= h || z >= l {
+						continue
+					}
+					if i := grid[(z*h+y)*w+x]; i >= 0 {
+						d := points[i].Sub(p)
+						if d.Dot(d) < radius*radius {
+							return false
+						}
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	add(s.InAABB(box))
+	for len(active) > 0 {
+		i := s.r.IntN(len(active))
+		p := points[active[i]]
+		found := false
+		for j := 0; j < k; j++ {
+			// Uniform by volume in the shell [radius, 2*radius]
+			d := radius * math.Cbrt(1+7*s.r.Float64())
+			q := p.Add(s.unitVector().Mul(d))
+			if !box.Contains(q) {
+				continue
+			}
+			if fits(q) {
+				add(q)
+				found = true
+				break
+			}
+		}
+		if !found {
+			active[i] = active[len(active)-1]
+			active = active[:len(active)-1]
+		}
+	}
+	return points
+}
+
+func clampInt(v, n int) int {
+	if v < 0 {
+		return 0
+	}
+	if v >= n {
+		return n - 1
+	}
+	return v
+}
@@ -0,0 +1,59 @@
+package sampling
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+func TestSampler_PoissonDisk2(t *testing.T) {
+	min, max := vectozavr.NewVec2(0, 0), vectozavr.NewVec2(10, 5)
+	const radius = 0.5
+	points := NewSeeded(11).PoissonDisk2(min, max, radius, 30)
+	if len(points) < 50 {
+		t.Fatalf("PoissonDisk2() returned only %d points", len(points))
+	}
+	for i, p := range points {
+		if p.X < min.X || p.Y < min.Y || p.X > max.X || p.Y > max.Y {
+			t.Errorf("point %v is outside the rectangle", p)
+		}
+		for _, q := range points[i+1:] {
+			if d, _ := p.Sub(q).Len(); d < radius {
+				t.Errorf("points %v and %v are %v apart", p, q, d)
+			}
+		}
+	}
+	if again := NewSeeded(11).PoissonDisk2(min, max, radius, 30); !reflect.DeepEqual(points, again) {
+		t.Errorf("PoissonDisk2() is not deterministic for the same seed")
+	}
+}
+
+func TestSampler_PoissonDisk3(t *testing.T) {
+	box := vectozavr.AABB{Min: vectozavr.NewVec3(-1, -1, -1), Max: vectozavr.NewVec3(1, 1, 1)}
+	const radius = 0.3
+	points := NewSeeded(5).PoissonDisk3(box, radius, 30)
+	if len(points) < 50 {
+		t.Fatalf("PoissonDisk3() returned only %d points", len(points))
+	}
+	for i, p := range points {
+		if !box.Contains(p) {
+			t.Errorf("point %v is outside the box", p)
+		}
+		for _, q := range points[i+1:] {
+			if d, _ := p.Sub(q).Len(); d < radius {
+				t.Errorf("points %v and %v are %v apart", p, q, d)
+			}
+		}
+	}
+}
+
+func TestSampler_PoissonDiskEmpty(t *testing.T) {
+	s := NewSeeded(1)
+	if got := s.PoissonDisk2(vectozavr.NewVec2(0, 0), vectozavr.NewVec2(1, 1), 0, 30); got != nil {
+		t.Errorf("PoissonDisk2() with zero radius = %v, want nil", got)
+	}
+	if got := s.PoissonDisk3(vectozavr.NewAABB(), 0.1, 30); got != nil {
+		t.Errorf("PoissonDisk3() with an empty box = %v, want nil", got)
+	}
+}
@@ -0,0 +1,111 @@
+package sampling
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+// Draws geometric samples from an injectable random source
+type Sampler struct {
+	r *rand.Rand
+}
+
+// Creates a sampler reading from the given source
+func New(src rand.Source) *Sampler {
+	return &Sampler{r: rand.New(src)}
+}
+
+// Creates a sampler with a PCG source initialised from the seed
+func NewSeeded(seed uint64) *Sampler {
+	return New(rand.NewPCG(seed, seed^0xda942042e4dd58b5))
+}
+
+// Uniform point inside a sphere
+func (s *Sampler) InSphere(center vectozavr.Vec3, radius float64) vectozavr.Vec3 {
+	r := radius * math.Cbrt(s.r.Float64())
+	return center.Add(s.unitVector().Mul(r))
+}
+
+// Uniform point on the surface of a sphere
+func (s *Sampler) OnSphere(center vectozavr.Vec3, radius float64) vectozavr.Vec3 {
+	return center.Add(s.unitVector().Mul(radius))
+}
+
+// Uniform unit direction in the hemisphere around the normal
+func (s *Sampler) InHemisphere(normal vectozavr.Vec3) vectozavr.Vec3 {
+	cosTheta := s.r.Float64()
+	return s.aroundAxis(normal, cosTheta)
+}
+
+// Cosine-weighted unit direction in the hemisphere around the normal
+func (s *Sampler) CosineHemisphere(normal vectozavr.Vec3) vectozavr.Vec3 {
+	cosTheta := math.Sqrt(s.r.Float64())
+	return s.aroundAxis(normal, cosTheta)
+}
+
+// Uniform unit direction inside a cone around the axis with the given half angle
+func (s *Sampler) InCone(axis vectozavr.Vec3, halfAngle vectozavr.Radians) vectozavr.Vec3 {
+	cosTheta := 1 - s.r.Float64()*(1-halfAngle.Cos())
+	return s.aroundAxis(axis, cosTheta)
+}
+
+// Uniform point inside a disk
+func (s *Sampler) InDisk(center vectozavr.Vec2, radius float64) vectozavr.Vec2 {
+	r := radius * math.Sqrt(s.r.Float64())
+	phi := 2 * math.Pi * s.r.Float64()
+	return center.Add(vectozavr.NewVec2(r*math.Cos(phi), r*math.Sin(phi)))
+}
+
+// Uniform point inside a triangle
+func (s *Sampler) InTriangle(a, b, c vectozavr.Vec3) vectozavr.Vec3 {
+	u, v := s.r.Float64(), s.r.Float64()
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	return a.Add(b.Sub(a).Mul(u)).Add(c.Sub(a).Mul(v))
+}
+
+// Uniform point inside a box
+func (s *Sampler) InAABB(box vectozavr.AABB) vectozavr.Vec3 {
+	size := box.Size()
+	return box.Min.Add(vectozavr.NewVec3(
+		s.r.Float64()*size.X,
+		s.r.Float64()*size.Y,
+		s.r.Float64()*size.Z,
+	))
+}
+
+// Uniform unit vector
+func (s *Sampler) unitVector() vectozavr.Vec3 {
+	z := 2*s.r.Float64() - 1
+	phi := 2 * math.Pi * s.r.Float64()
+	r := math.Sqrt(1 - z*z)
+	return vectozavr.NewVec3(r*math.Cos(phi), r*math.Sin(phi), z)
+}
+
+// Unit direction at the given polar angle cosine from the axis, uniform in azimuth.
+// A zero axis gives a zero vector.
+func (s *Sampler) aroundAxis(axis vectozavr.Vec3, cosTheta float64) vectozavr.Vec3 {
+	w, err := axis.Normalize()
+	if err != nil {
+		return vectozavr.ZeroVec3()
+	}
+	u, v := orthonormal(w)
+	sinTheta := math.Sqrt(math.Max(0, 1-cosTheta*cosTheta))
+	phi := 2 * math.Pi * s.r.Float64()
+	return u.Mul(sinTheta * math.Cos(phi)).
+		Add(v.Mul(sinTheta * math.Sin(phi))).
+		Add(w.Mul(cosTheta))
+}
+
+// Two unit vectors completing the unit vector w to an orthonormal basis
+func orthonormal(w vectozavr.Vec3) (vectozavr.Vec3, vectozavr.Vec3) {
+	a := vectozavr.NewVec3(1, 0, 0)
+	if math.Abs(w.X) > 0.9 {
+		a = vectozavr.NewVec3(0, 1, 0)
+	}
+	u, _ := w.Cross(a).Normalize()
+	return u, w.Cross(u)
+}
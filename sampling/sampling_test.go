@@ -0,0 +1,114 @@
+package sampling
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/rudolfkova/vectozavr/vectozavr"
+)
+
+const samples = 2000
+
+func length(v vectozavr.Vec3) float64 {
+	l, _ := v.Len()
+	return l
+}
+
+func TestSampler_Deterministic(t *testing.T) {
+	a, b := NewSeeded(7), NewSeeded(7)
+	for i := 0; i < 10; i++ {
+		pa := a.InSphere(vectozavr.ZeroVec3(), 1)
+		pb := b.InSphere(vectozavr.ZeroVec3(), 1)
+		if !reflect.DeepEqual(pa, pb) {
+			t.Fatalf("sample %d: %v != %v", i, pa, pb)
+		}
+	}
+}
+
+func TestSampler_Vec3(t *testing.T) {
+	center := vectozavr.NewVec3(1, 2, 3)
+	normal := vectozavr.NewVec3(0, 0, 2)
+	tri := [3]vectozavr.Vec3{vectozavr.NewVec3(0, 0, 0), vectozavr.NewVec3(2, 0, 0), vectozavr.NewVec3(0, 2, 0)}
+	box := vectozavr.AABB{Min: vectozavr.NewVec3(-1, 0, 2), Max: vectozavr.NewVec3(1, 3, 2.5)}
+	tests := []struct {
+		name   string
+		sample func(s *Sampler) vectozavr.Vec3
+		check  func(p vectozavr.Vec3) bool
+	}{
+		{
+			name:   "testInSphere",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.InSphere(center, 2) },
+			check:  func(p vectozavr.Vec3) bool { return length(p.Sub(center)) <= 2 },
+		},
+		{
+			name:   "testOnSphere",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.OnSphere(center, 2) },
+			check:  func(p vectozavr.Vec3) bool { return math.Abs(length(p.Sub(center))-2) < 1e-9 },
+		},
+		{
+			name:   "testInHemisphere",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.InHemisphere(normal) },
+			check:  func(p vectozavr.Vec3) bool { return p.Z >= 0 && math.Abs(length(p)-1) < 1e-9 },
+		},
+		{
+			name:   "testCosineHemisphere",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.CosineHemisphere(normal) },
+			check:  func(p vectozavr.Vec3) bool { return p.Z >= 0 && math.Abs(length(p)-1) < 1e-9 },
+		},
+		{
+			name:   "testInCone",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.InCone(normal, math.Pi/6) },
+			check:  func(p vectozavr.Vec3) bool { return p.Z >= math.Cos(math.Pi/6)-1e-9 },
+		},
+		{
+			name:   "testInTriangle",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.InTriangle(tri[0], tri[1], tri[2]) },
+			check:  func(p vectozavr.Vec3) bool { return p.X >= 0 && p.Y >= 0 && p.X+p.Y <= 2+1e-9 && p.Z == 0 },
+		},
+		{
+			name:   "testInAABB",
+			sample: func(s *Sampler) vectozavr.Vec3 { return s.InAABB(box) },
+			check:  box.Contains,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSeeded(1)
+			for i := 0; i < samples; i++ {
+				if p := tt.sample(s); !tt.check(p) {
+					t.Fatalf("sample %d = %v is out of the domain", i, p)
+				}
+			}
+		})
+	}
+}
+
+func TestSampler_InDisk(t *testing.T) {
+	s := NewSeeded(1)
+	center := vectozavr.NewVec2(-1, 4)
+	for i := 0; i < samples; i++ {
+		p := s.InDisk(center, 0.5)
+		if l, _ := p.Sub(center).Len(); l > 0.5 {
+			t.Fatalf("sample %d = %v is outside the disk", i, p)
+		}
+	}
+}
+
+// The mean cosine of a cosine-weighted hemisphere is 2/3, of a uniform one 1/2
+func TestSampler_HemisphereMean(t *testing.T) {
+	s := NewSeeded(3)
+	up := vectozavr.NewVec3(0, 1, 0)
+	var cosine, uniform float64
+	const n = 20000
+	for i := 0; i < n; i++ {
+		cosine += s.CosineHemisphere(up).Y
+		uniform += s.InHemisphere(up).Y
+	}
+	if got := cosine / n; math.Abs(got-2.0/3.0) > 0.01 {
+		t.Errorf("cosine-weighted mean = %v, want 2/3", got)
+	}
+	if got := uniform / n; math.Abs(got-0.5) > 0.01 {
+		t.Errorf("uniform mean = %v, want 1/2", got)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/rudolfkova/vectozavr/camera"
+)
+
+// File the cameras of the last session are kept in
+const sessionFile = "camera_session.json"
+
+// Saves the camera of every pane under the pane's name
+func (g *Game) saveSession() error {
+	cams := map[string]*camera.Camera{}
+	for _, p := range g.panes {
+		cams[p.name] = p.cam
+	}
+	f, err := os.Create(sessionFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(cams)
+}
+
+// Restores the cameras saved by saveSession. Without a saved session the
+// cameras keep their defaults, and so does a camera that cannot be
+// restored; the others are restored anyway and the errors joined.
+func (g *Game) loadSession() error {
+	f, err := os.Open(sessionFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	var cams map[string]json.RawMessage
+	if err := json.NewDecoder(f).Decode(&cams); err != nil {
+		return fmt.Errorf("cannot read %s: %v", sessionFile, err)
+	}
+	var errs []error
+	for _, p := range g.panes {
+		if data, ok := cams[p.name]; ok {
+			if err := json.Unmarshal(data, p.cam); err != nil {
+				errs = append(errs, fmt.Errorf("cannot restore the %s camera: %v", p.name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Camera presets are stored next to the scene they belong to
+func (g *Game) presetsFile() string {
+	return g.scene + ".cameras.json"
+}
+
+func (g *Game) savePresets() error {
+	f, err := os.Create(g.presetsFile())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.presets.Save(f)
+}
+
+// Loads the presets of the scene, if it has any
+func (g *Game) loadPresets() error {
+	f, err := os.Open(g.presetsFile())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	p, err := camera.LoadPresets(f)
+	if err != nil {
+		return err
+	}
+	g.presets = p
+	return nil
+}
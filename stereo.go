@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/colorm"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/rudolfkova/vectozavr/camera"
+)
+
+// How the two eye images are shown
+type stereoMode int
+
+const (
+	stereoOff stereoMode = iota
+	// Red left eye and cyan right eye on top of each other
+	stereoAnaglyph
+	// Left eye in the left half, right eye in the right half
+	stereoSideBySide
+	// Left eye in the top half, right eye in the bottom half
+	stereoOverUnder
+	stereoModes
+)
+
+var stereoModeNames = [...]string{"off", "anaglyph", "side-by-side", "over-under"}
+
+func (m stereoMode) String() string {
+	if m < 0 || m >= stereoModes {
+		return fmt.Sprintf("stereoMode(%d)", int(m))
+	}
+	return stereoModeNames[m]
+}
+
+// V cycles the stereo output, [ and ] change the eye separation,
+// - and = the convergence distance
+func (g *Game) stereoKeys() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyV) {
+		g.stereoMode = (g.stereoMode + 1) % stereoModes
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketLeft) {
+		g.stereo.EyeSeparation /= 1.25
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBracketRight) {
+		g.stereo.EyeSeparation *= 1.25
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		g.stereo.Convergence /= 1.25
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		g.stereo.Convergence *= 1.25
+	}
+}
+
+// Luminance weights of the red, green and blue channels
+var luminance = [3]float64{0.299, 0.587, 0.114}
+
+// Draws the pane once per eye and composes the two images
+func (g *Game) drawStereo(screen *ebiten.Image, p *pane) {
+	vp := p.cam.Viewport
+	switch g.stereoMode {
+	case stereoSideBySide, stereoOverUnder:
+		lvp, rvp := vp, vp
+		if g.stereoMode == stereoSideBySide {
+			lvp.Width = vp.Width / 2
+			rvp.X, rvp.Width = vp.X+lvp.Width, vp.Width-lvp.Width
+		} else {
+			lvp.Height = vp.Height / 2
+			rvp.Y, rvp.Height = vp.Y+lvp.Height, vp.Height-lvp.Height
+		}
+		l, r := p.cam.Eyes(g.stereo, lvp, rvp)
+		for _, eye := range []*camera.Camera{l, r} {
+			evp := eye.Viewport
+			sub := screen.SubImage(image.Rect(evp.X, evp.Y, evp.X+evp.Width, evp.Y+evp.Height)).(*ebiten.Image)
+			g.drawScene(sub, eye, p.axis)
+		}
+	case stereoAnaglyph:
+		l, r := p.cam.Eyes(g.stereo, vp, vp)
+		for i, eye := range []*camera.Camera{l, r} {
+			img := g.eyeImage(i)
+			img.Clear()
+			g.drawScene(img, eye, p.axis)
+
+			// The left eye's luminance goes to red, the right eye's to green and blue
+			var cm colorm.ColorM
+			for out := 0; out < 3; out++ {
+				left := out == 0
+				for in, w := range luminance {
+					if left != (i == 0) {
+						w = 0
+					}
+					cm.SetElement(out, in, w)
+				}
+			}
+			colorm.DrawImage(screen, img, cm, &colorm.DrawImageOptions{Blend: ebiten.BlendLighter})
+		}
+	}
+}
+
+// Offscreen image for one eye, the size of the window
+func (g *Game) eyeImage(i int) *ebiten.Image {
+	img := g.eyeImages[i]
+	if img == nil || img.Bounds().Dx() != g.w || img.Bounds().Dy() != g.h {
+		if img != nil {
+			img.Deallocate()
+		}
+		img = ebiten.NewImage(g.w, g.h)
+		g.eyeImages[i] = img
+	}
+	return img
+}
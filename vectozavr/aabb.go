@@ -0,0 +1,87 @@
+package vectozavr
+
+import (
+	"math"
+)
+
+// An axis-aligned bounding box
+type AABB struct {
+	Min, Max Vec3
+}
+
+// Creates the smallest box enclosing the given points; without points the box is empty
+func NewAABB(points ...Vec3) AABB {
+	inf := math.Inf(1)
+	b := AABB{Min: NewVec3(inf, inf, inf), Max: NewVec3(-inf, -inf, -inf)}
+	for _, p := range points {
+		b = b.Extend(p)
+	}
+	return b
+}
+
+// Reports whether the box contains no points
+func (b AABB) Empty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y || b.Min.Z > b.Max.Z
+}
+
+// Grows the box to enclose the point
+func (b AABB) Extend(p Vec3) AABB {
+	return AABB{
+		Min: NewVec3(math.Min(b.Min.X, p.X), math.Min(b.Min.Y, p.Y), math.Min(b.Min.Z, p.Z)),
+		Max: NewVec3(math.Max(b.Max.X, p.X), math.Max(b.Max.Y, p.Y), math.Max(b.Max.Z, p.Z)),
+	}
+}
+
+// The smallest box enclosing both boxes
+func (b AABB) Union(b2 AABB) AABB {
+	if b2.Empty() {
+		return b
+	}
+	return b.Extend(b2.Min).Extend(b2.Max)
+}
+
+// Returns the centre of the box
+func (b AABB) Center() Vec3 {
+	return b.Min.Add(b.Max).Mul(0.5)
+}
+
+// Returns the edge lengths of the box
+func (b AABB) Size() Vec3 {
+	return b.Max.Sub(b.Min)
+}
+
+// Reports whether the point lies inside the box or on its boundary
+func (b AABB) Contains(p Vec3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Returns the point of the box closest to p
+func (b AABB) Clamp(p Vec3) Vec3 {
+	return NewVec3(
+		math.Max(b.Min.X, math.Min(b.Max.X, p.X)),
+		math.Max(b.Min.Y, math.Min(b.Max.Y, p.Y)),
+		math.Max(b.Min.Z, math.Min(b.Max.Z, p.Z)),
+	)
+}
+
+// Returns the eight corners of the box; bit 0 of the index picks Max.X,
+// bit 1 Max.Y and bit 2 Max.Z
+func (b AABB) Corners() []Vec3 {
+	corners := make([]Vec3, 8)
+	for i := range corners {
+		c := b.Min
+		if i&1 != 0 {
+			c.X = b.Max.X
+		}
+		if i&2 != 0 {
+			c.Y = b.Max.Y
+		}
+		if i&4 != 0 {
+			c.Z = b.Max.Z
+		}
+		corners[i] = c
+	}
+	return corners
+}
@@ -0,0 +1,65 @@
+package vectozavr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewAABB(t *testing.T) {
+	tests := []struct {
+		name      string
+		points    []Vec3
+		want      AABB
+		wantEmpty bool
+	}{
+		{
+			name:   "testPoints",
+			points: []Vec3{{1, -2, 3}, {-1, 4, 0}, {0, 0, 5}},
+			want:   AABB{Min: Vec3{-1, -2, 0}, Max: Vec3{1, 4, 5}},
+		},
+		{
+			name:   "testSinglePoint",
+			points: []Vec3{{1, 2, 3}},
+			want:   AABB{Min: Vec3{1, 2, 3}, Max: Vec3{1, 2, 3}},
+		},
+		{
+			name:      "testEmpty",
+			points:    nil,
+			want:      NewAABB(),
+			wantEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewAABB(tt.points...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAABB() = %v, want %v", got, tt.want)
+			}
+			if got.Empty() != tt.wantEmpty {
+				t.Errorf("AABB.Empty() = %v, want %v", got.Empty(), tt.wantEmpty)
+			}
+		})
+	}
+}
+
+func TestAABB_Clamp(t *testing.T) {
+	b := AABB{Min: Vec3{-1, -1, -1}, Max: Vec3{1, 2, 3}}
+	tests := []struct {
+		name string
+		p    Vec3
+		want Vec3
+	}{
+		{name: "testInside", p: Vec3{0, 1, 2}, want: Vec3{0, 1, 2}},
+		{name: "testOutside", p: Vec3{5, -4, 10}, want: Vec3{1, -1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Clamp(tt.p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AABB.Clamp() = %v, want %v", got, tt.want)
+			}
+			if !b.Contains(b.Clamp(tt.p)) {
+				t.Errorf("AABB.Contains(%v) = false", b.Clamp(tt.p))
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package vectozavr
+
+import (
+	"math"
+)
+
+// An angle in radians
+type Radians float64
+
+// An angle in degrees
+type Degrees float64
+
+// Converting degrees to radians
+func (d Degrees) Radians() Radians {
+	return Radians(float64(d) * math.Pi / 180)
+}
+
+// Converting radians to degrees
+func (r Radians) Degrees() Degrees {
+	return Degrees(float64(r) * 180 / math.Pi)
+}
+
+// Wraps the angle to [-π, π)
+func (r Radians) Wrap() Radians {
+	return Radians(wrap(float64(r)+math.Pi, 2*math.Pi) - math.Pi)
+}
+
+// Wraps the angle to [0, 2π)
+func (r Radians) WrapPositive() Radians {
+	return Radians(wrap(float64(r), 2*math.Pi))
+}
+
+// Wraps the angle to [-180, 180)
+func (d Degrees) Wrap() Degrees {
+	return Degrees(wrap(float64(d)+180, 360) - 180)
+}
+
+// Wraps the angle to [0, 360)
+func (d Degrees) WrapPositive() Degrees {
+	return Degrees(wrap(float64(d), 360))
+}
+
+// Limits the angle to [min, max]
+func (r Radians) Clamp(min, max Radians) Radians {
+	return Radians(math.Max(float64(min), math.Min(float64(max), float64(r))))
+}
+
+func (r Radians) Sin() float64 {
+	return math.Sin(float64(r))
+}
+
+func (r Radians) Cos() float64 {
+	return math.Cos(float64(r))
+}
+
+func (r Radians) Tan() float64 {
+	return math.Tan(float64(r))
+}
+
+// The signed shortest rotation from one angle to another, in [-π, π)
+func AngleDiff(from, to Radians) Radians {
+	return (to - from).Wrap()
+}
+
+// Interpolates between two angles along the shortest arc
+func LerpAngle(from, to Radians, t float64) Radians {
+	return from + AngleDiff(from, to)*Radians(t)
+}
+
+// Reduces x to [0, period)
+func wrap(x, period float64) float64 {
+	x = math.Mod(x, period)
+	if x < 0 {
+		x += period
+	}
+	// x + period can round up to exactly period for tiny negative x
+	if x >= period {
+		x = 0
+	}
+	return x
+}
@@ -0,0 +1,82 @@
+package vectozavr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDegrees_Radians(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Degrees
+		want Radians
+	}{
+		{name: "testZero", d: 0, want: 0},
+		{name: "testRight", d: 90, want: math.Pi / 2},
+		{name: "testNegative", d: -180, want: -math.Pi},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Radians(); math.Abs(float64(got-tt.want)) > eps {
+				t.Errorf("Degrees.Radians() = %v, want %v", got, tt.want)
+			}
+			if got := tt.want.Degrees(); math.Abs(float64(got-tt.d)) > eps {
+				t.Errorf("Radians.Degrees() = %v, want %v", got, tt.d)
+			}
+		})
+	}
+}
+
+func TestRadians_Wrap(t *testing.T) {
+	tests := []struct {
+		name         string
+		r            Radians
+		want         Radians
+		wantPositive Radians
+	}{
+		{name: "testInside", r: 1, want: 1, wantPositive: 1},
+		{name: "testNegative", r: -1, want: -1, wantPositive: 2*math.Pi - 1},
+		{name: "testPi", r: math.Pi, want: -math.Pi, wantPositive: math.Pi},
+		{name: "testMinusPi", r: -math.Pi, want: -math.Pi, wantPositive: math.Pi},
+		{name: "testTurns", r: 5*math.Pi + 0.5, want: -math.Pi + 0.5, wantPositive: math.Pi + 0.5},
+		{name: "testTinyNegative", r: -1e-17, want: -1e-17, wantPositive: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Wrap(); math.Abs(float64(got-tt.want)) > eps || got < -math.Pi || got >= math.Pi {
+				t.Errorf("Radians.Wrap() = %v, want %v", got, tt.want)
+			}
+			if got := tt.r.WrapPositive(); math.Abs(float64(got-tt.wantPositive)) > eps || got < 0 || got >= 2*math.Pi {
+				t.Errorf("Radians.WrapPositive() = %v, want %v", got, tt.wantPositive)
+			}
+		})
+	}
+}
+
+func TestDegrees_Wrap(t *testing.T) {
+	if got := Degrees(540).Wrap(); got != -180 {
+		t.Errorf("Degrees.Wrap() = %v, want -180", got)
+	}
+	if got := Degrees(-90).WrapPositive(); got != 270 {
+		t.Errorf("Degrees.WrapPositive() = %v, want 270", got)
+	}
+}
+
+func TestAngleDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Radians
+		want     Radians
+	}{
+		{name: "testSimple", from: 0.5, to: 1.5, want: 1},
+		{name: "testAcrossZero", from: 2*math.Pi - 0.1, to: 0.1, want: 0.2},
+		{name: "testBackwards", from: 0.1, to: 2*math.Pi - 0.1, want: -0.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AngleDiff(tt.from, tt.to); math.Abs(float64(got-tt.want)) > eps {
+				t.Errorf("AngleDiff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
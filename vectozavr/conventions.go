@@ -0,0 +1,192 @@
+package vectozavr
+
+// Handedness of the world coordinate system
+type Handedness int
+
+const (
+	// Looking along the forward axis, +X points to the left of the screen
+	RightHanded Handedness = iota
+	// Looking along the forward axis, +X points to the right of the screen
+	LeftHanded
+)
+
+// The world axis pointing up
+type UpAxis int
+
+const (
+	YUp UpAxis = iota
+	ZUp
+)
+
+// Range of the normalised depth after projection
+type DepthRange int
+
+const (
+	// Near plane maps to 0, far plane to 1 (Direct3D, Vulkan)
+	DepthZeroToOne DepthRange = iota
+	// Near plane maps to -1, far plane to 1 (OpenGL)
+	DepthMinusOneToOne
+)
+
+// Corner of the screen where pixel coordinates start. Drawing always uses
+// the top-left origin; the convention only applies to coordinates read or
+// reported by the program, see ScreenPoint
+type ScreenOrigin int
+
+const (
+	OriginTopLeft ScreenOrigin = iota
+	OriginBottomLeft
+)
+
+// Coordinate-system conventions used by projection, screen space and cameras.
+// The zero value is the default: right-handed, Y-up, depth 0..1, top-left origin.
+type Conventions struct {
+	Handedness Handedness
+	Up         UpAxis
+	Depth      DepthRange
+	Origin     ScreenOrigin
+}
+
+// Right-handed, Y-up, depth 0..1, top-left origin
+func DefaultConventions() Conventions {
+	return Conventions{}
+}
+
+// Right-handed, Z-up, as exported by Blender and most CAD tools
+func BlenderConventions() Conventions {
+	return Conventions{Handedness: RightHanded, Up: ZUp, Depth: DepthMinusOneToOne, Origin: OriginBottomLeft}
+}
+
+// Left-handed, Y-up, as used by Unity
+func UnityConventions() Conventions {
+	return Conventions{Handedness: LeftHanded, Up: YUp, Depth: DepthZeroToOne, Origin: OriginTopLeft}
+}
+
+// Right-handed, Y-up, depth -1..1, bottom-left origin
+func OpenGLConventions() Conventions {
+	return Conventions{Handedness: RightHanded, Up: YUp, Depth: DepthMinusOneToOne, Origin: OriginBottomLeft}
+}
+
+// The world up direction
+func (c Conventions) UpVector() Vec3 {
+	if c.Up == ZUp {
+		return NewVec3(0, 0, 1)
+	}
+	return NewVec3(0, 1, 0)
+}
+
+// The default viewing direction of a camera
+func (c Conventions) ForwardVector() Vec3 {
+	if c.Up == ZUp {
+		return NewVec3(0, 1, 0)
+	}
+	return NewVec3(0, 0, 1)
+}
+
+// The first axis of the view basis, Up × Forward
+func (c Conventions) SideVector() Vec3 {
+	return c.UpVector().Cross(c.ForwardVector())
+}
+
+// Coefficients of the depth row of the projection: z' = a·z + b
+func (c Conventions) depth(ZNear, ZFar float64) (a, b float64) {
+	if c.Depth == DepthMinusOneToOne {
+		return (ZFar + ZNear) / (ZFar - ZNear), -2 * ZFar * ZNear / (ZFar - ZNear)
+	}
+	return ZFar / (ZFar - ZNear), -ZFar * ZNear / (ZFar - ZNear)
+}
+
+// Создаёт матрицу проекции
+func (c Conventions) Projection(fov Degrees, aspect, ZNear, ZFar float64) Matrix {
+	tanHalfFov := (fov / 2).Radians().Tan()
+	a, b := c.depth(ZNear, ZFar)
+
+	return NewMatrix([4][4]float64{
+		{1.0 / (tanHalfFov * aspect), 0, 0, 0},
+		{0, 1.0 / tanHalfFov, 0, 0},
+		{0, 0, a, b},
+		{0, 0, 1, 0},
+	})
+}
+
+func (c Conventions) InverseProjection(fov Degrees, aspect, ZNear, ZFar float64) Matrix {
+	tanHalfFov := (fov / 2).Radians().Tan()
+	a, b := c.depth(ZNear, ZFar)
+
+	return NewMatrix([4][4]float64{
+		{tanHalfFov * aspect, 0, 0, 0},
+		{0, tanHalfFov, 0, 0},
+		{0, 0, 0, 1},
+		{0, 0, 1 / b, -a / b},
+	})
+}
+
+// Coefficients of the depth row of the orthographic projection: z' = a·z + b
+func (c Conventions) orthoDepth(ZNear, ZFar float64) (a, b float64) {
+	if c.Depth == DepthMinusOneToOne {
+		return 2 / (ZFar - ZNear), -(ZFar + ZNear) / (ZFar - ZNear)
+	}
+	return 1 / (ZFar - ZNear), -ZNear / (ZFar - ZNear)
+}
+
+// Orthographic projection of a view volume height units tall
+func (c Conventions) Orthographic(height, aspect, ZNear, ZFar float64) Matrix {
+	a, b := c.orthoDepth(ZNear, ZFar)
+
+	return NewMatrix([4][4]float64{
+		{2 / (height * aspect), 0, 0, 0},
+		{0, 2 / height, 0, 0},
+		{0, 0, a, b},
+		{0, 0, 0, 1},
+	})
+}
+
+func (c Conventions) InverseOrthographic(height, aspect, ZNear, ZFar float64) Matrix {
+	a, b := c.orthoDepth(ZNear, ZFar)
+
+	return NewMatrix([4][4]float64{
+		{height * aspect / 2, 0, 0, 0},
+		{0, height / 2, 0, 0},
+		{0, 0, 1 / a, -b / a},
+		{0, 0, 0, 1},
+	})
+}
+
+// Signs of the screen axes relative to the normalised device axes
+func (c Conventions) screenSigns() (sx, sy float64) {
+	sx, sy = -1, -1
+	if c.Handedness == LeftHanded {
+		sx = 1
+	}
+	return sx, sy
+}
+
+// Converts a top-left pixel position on a screen height pixels tall to
+// and from the convention's origin
+func (c Conventions) ScreenPoint(p Vec2, height float64) Vec2 {
+	if c.Origin == OriginBottomLeft {
+		p.Y = height - p.Y
+	}
+	return p
+}
+
+// Создаёт матрицу экранного пространства
+func (c Conventions) ScreenSpace(width, height float64) Matrix {
+	sx, sy := c.screenSigns()
+	return NewMatrix([4][4]float64{
+		{sx * 0.5 * width, 0, 0, 0.5 * width},
+		{0, sy * 0.5 * height, 0, 0.5 * height},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
+
+func (c Conventions) InverseScreenSpace(width, height float64) Matrix {
+	sx, sy := c.screenSigns()
+	return NewMatrix([4][4]float64{
+		{sx * 2.0 / width, 0, 0, -sx},
+		{0, sy * 2.0 / height, 0, -sy},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+}
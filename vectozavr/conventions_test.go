@@ -0,0 +1,116 @@
+package vectozavr
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestConventions_Default(t *testing.T) {
+	c := DefaultConventions()
+	// The default conventions keep the historical matrices
+	wantP := NewMatrix([4][4]float64{
+		{1.0 / (math.Tan(math.Pi/6) * 2), 0, 0, 0},
+		{0, 1.0 / math.Tan(math.Pi/6), 0, 0},
+		{0, 0, 10.0 / 9.0, -10.0 / 9.0},
+		{0, 0, 1, 0},
+	})
+	if got := c.Projection(60, 2, 1, 10); !matrixNear(got, wantP) {
+		t.Errorf("Conventions.Projection() = %v, want %v", got, wantP)
+	}
+	wantS := NewMatrix([4][4]float64{
+		{-50, 0, 0, 50},
+		{0, -25, 0, 25},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+	})
+	if got := c.ScreenSpace(100, 50); !reflect.DeepEqual(got, wantS) {
+		t.Errorf("Conventions.ScreenSpace() = %v, want %v", got, wantS)
+	}
+	if got := c.SideVector(); !reflect.DeepEqual(got, NewVec3(1, 0, 0)) {
+		t.Errorf("Conventions.SideVector() = %v, want (1, 0, 0)", got)
+	}
+}
+
+func TestConventions_Depth(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Conventions
+		wantNear float64
+		wantFar  float64
+	}{
+		{name: "testDefault", c: DefaultConventions(), wantNear: 0, wantFar: 1},
+		{name: "testOpenGL", c: OpenGLConventions(), wantNear: -1, wantFar: 1},
+		{name: "testUnity", c: UnityConventions(), wantNear: 0, wantFar: 1},
+		{name: "testBlender", c: BlenderConventions(), wantNear: -1, wantFar: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.c.Projection(45, 1.5, 2, 20)
+			depth := func(z float64) float64 {
+				v := p.Vec4Mul(NewVec4(0, 0, z, 1))
+				return v.Z / v.W
+			}
+			if got := depth(2); math.Abs(got-tt.wantNear) > eps {
+				t.Errorf("near depth = %v, want %v", got, tt.wantNear)
+			}
+			if got := depth(20); math.Abs(got-tt.wantFar) > eps {
+				t.Errorf("far depth = %v, want %v", got, tt.wantFar)
+			}
+			o := tt.c.Orthographic(4, 1.5, 2, 20)
+			if got := o.Vec4Mul(NewVec4(0, 0, 2, 1)).Z; math.Abs(got-tt.wantNear) > eps {
+				t.Errorf("orthographic near depth = %v, want %v", got, tt.wantNear)
+			}
+			if got := o.Vec4Mul(NewVec4(3, 2, 20, 1)); math.Abs(got.X-1) > eps || math.Abs(got.Y-1) > eps || math.Abs(got.Z-tt.wantFar) > eps {
+				t.Errorf("orthographic corner = %v, want (1, 1, %v)", got, tt.wantFar)
+			}
+			if got := tt.c.InverseOrthographic(4, 1.5, 2, 20).MatMul(o); !matrixNear(got, Identity()) {
+				t.Errorf("InverseOrthographic()·Orthographic() = %v, want identity", got)
+			}
+
+			inv := tt.c.InverseProjection(45, 1.5, 2, 20)
+			if got := inv.MatMul(p); !matrixNear(got, Identity()) {
+				t.Errorf("InverseProjection()·Projection() = %v, want identity", got)
+			}
+			s := tt.c.ScreenSpace(640, 480)
+			if got := tt.c.InverseScreenSpace(640, 480).MatMul(s); !matrixNear(got, Identity()) {
+				t.Errorf("InverseScreenSpace()·ScreenSpace() = %v, want identity", got)
+			}
+		})
+	}
+}
+
+func TestConventions_Screen(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Conventions
+		// Screen position of the normalised point (1, 1)
+		want Vec4
+	}{
+		{name: "testRightTopLeft", c: Conventions{Handedness: RightHanded, Origin: OriginTopLeft}, want: NewVec4(0, 0, 0, 1)},
+		{name: "testLeftTopLeft", c: Conventions{Handedness: LeftHanded, Origin: OriginTopLeft}, want: NewVec4(200, 0, 0, 1)},
+		{name: "testRightBottomLeft", c: Conventions{Handedness: RightHanded, Origin: OriginBottomLeft}, want: NewVec4(0, 0, 0, 1)},
+		{name: "testLeftBottomLeft", c: Conventions{Handedness: LeftHanded, Origin: OriginBottomLeft}, want: NewVec4(200, 0, 0, 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.c.ScreenSpace(200, 100).Vec4Mul(NewVec4(1, 1, 0, 1)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScreenSpace() maps (1, 1) to %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConventions_ScreenPoint(t *testing.T) {
+	p := NewVec2(20, 30)
+	if got := DefaultConventions().ScreenPoint(p, 100); got != p {
+		t.Errorf("top-left ScreenPoint() = %v, want %v", got, p)
+	}
+	c := OpenGLConventions()
+	if got, want := c.ScreenPoint(p, 100), NewVec2(20, 70); got != want {
+		t.Errorf("bottom-left ScreenPoint() = %v, want %v", got, want)
+	}
+	if got := c.ScreenPoint(c.ScreenPoint(p, 100), 100); got != p {
+		t.Errorf("ScreenPoint() is not its own inverse: %v", got)
+	}
+}
@@ -2,7 +2,6 @@ package vectozavr
 
 import (
 	"errors"
-	"math"
 
 	"gonum.org/v1/gonum/mat"
 )
@@ -134,9 +133,9 @@ func Translation(v Vec3) Matrix {
 }
 
 // Матрица поворота вокруг оси X
-func RotationX(angle float64) Matrix {
-	c := math.Cos(angle)
-	s := math.Sin(angle)
+func RotationX(angle Radians) Matrix {
+	c := angle.Cos()
+	s := angle.Sin()
 
 	return NewMatrix([4][4]float64{
 		{1, 0, 0, 0},
@@ -148,9 +147,9 @@ func RotationX(angle float64) Matrix {
 }
 
 // Матрица поворота вокруг оси Y
-func RotationY(angle float64) Matrix {
-	c := math.Cos(angle)
-	s := math.Sin(angle)
+func RotationY(angle Radians) Matrix {
+	c := angle.Cos()
+	s := angle.Sin()
 
 	return NewMatrix([4][4]float64{
 		{c, 0, s, 0},
@@ -161,18 +160,18 @@ func RotationY(angle float64) Matrix {
 
 }
 
-func InverseRotationY(angle float64) Matrix {
+func InverseRotationY(angle Radians) Matrix {
 	return RotationY(-angle) // транспонирование для ортогональной матрицы
 }
 
-func InverseRotationX(angle float64) Matrix {
+func InverseRotationX(angle Radians) Matrix {
 	return RotationX(-angle) // транспонирование для ортогональной матрицы
 }
 
 // Матрица поворота вокруг оси Z
-func RotationZ(angle float64) Matrix {
-	c := math.Cos(angle)
-	s := math.Sin(angle)
+func RotationZ(angle Radians) Matrix {
+	c := angle.Cos()
+	s := angle.Sin()
 
 	return NewMatrix([4][4]float64{
 		{c, -s, 0, 0},
@@ -182,20 +181,21 @@ func RotationZ(angle float64) Matrix {
 	})
 }
 
-// Матрица поворота (по всем осям)
+// Матрица поворота (по всем осям), углы в радианах
 func Rotation(v Vec3) Matrix {
-	r := RotationX(v.X).MatMul(RotationY(v.Y))
-	return r.MatMul(RotationZ(v.Z))
+	r := RotationX(Radians(v.X)).MatMul(RotationY(Radians(v.Y)))
+	return r.MatMul(RotationZ(Radians(v.Z)))
 }
 
-func RotationV(v Vec3, a float64) Matrix {
+// Матрица поворота вокруг произвольной оси
+func RotationV(v Vec3, a Radians) Matrix {
 	var r Matrix
 	nv, err1 := v.Normalize()
 	if err1 != nil {
 		return ZeroMatrix()
 	}
-	c := math.Cos(a)
-	s := math.Sin(a)
+	c := a.Cos()
+	s := a.Sin()
 	r.m[0][0] = c + (1.0-c)*nv.X*nv.X
 	r.m[0][1] = (1.0-c)*nv.X*nv.Y - s*nv.Z
 	r.m[0][2] = (1.0-c)*nv.X*nv.Z + s*nv.Y
@@ -235,14 +235,9 @@ func (m Matrix) W() Vec3 {
 	return Vec3{m.m[0][3], m.m[1][3], m.m[2][3]}
 }
 
-// Создаёт патрицу проекции
-func Projection(fov float64, aspect, ZNear, ZFar float64) Matrix {
-	return NewMatrix([4][4]float64{
-		{1.0 / (math.Tan(math.Pi*fov*0.5/180) * aspect), 0, 0, 0},
-		{0, 1.0 / math.Tan(math.Pi*fov*0.5/180), 0, 0},
-		{0, 0, ZFar / (ZFar - ZNear), -ZFar * ZNear / (ZFar - ZNear)},
-		{0, 0, 1, 0},
-	})
+// Создаёт патрицу проекции (в соглашениях по умолчанию)
+func Projection(fov Degrees, aspect, ZNear, ZFar float64) Matrix {
+	return DefaultConventions().Projection(fov, aspect, ZNear, ZFar)
 }
 
 // func InverseProjection(fov float64, aspect, ZNear, ZFar float64) Matrix {
@@ -254,25 +249,13 @@ func Projection(fov float64, aspect, ZNear, ZFar float64) Matrix {
 // 	})
 // }
 
-func InverseProjection(fov, aspect, ZNear, ZFar float64) Matrix {
-	tanHalfFov := math.Tan(math.Pi * fov * 0.5 / 180)
-
-	return NewMatrix([4][4]float64{
-		{tanHalfFov * aspect, 0, 0, 0},
-		{0, tanHalfFov, 0, 0},
-		{0, 0, 0, 1},
-		{0, 0, (ZNear - ZFar) / (ZFar * ZNear), ZFar / (ZFar * ZNear)},
-	})
+func InverseProjection(fov Degrees, aspect, ZNear, ZFar float64) Matrix {
+	return DefaultConventions().InverseProjection(fov, aspect, ZNear, ZFar)
 }
 
-// Создаёт матрицу экранного пространства
+// Создаёт матрицу экранного пространства (в соглашениях по умолчанию)
 func ScreenSpace(width, height float64) Matrix {
-	return NewMatrix([4][4]float64{
-		{-0.5 * width, 0, 0, 0.5 * width},
-		{0, -0.5 * height, 0, 0.5 * height},
-		{0, 0, 1, 0},
-		{0, 0, 0, 1},
-	})
+	return DefaultConventions().ScreenSpace(width, height)
 }
 
 // func InverseScreenSpace(width, height float64) Matrix {
@@ -285,12 +268,7 @@ func ScreenSpace(width, height float64) Matrix {
 // }
 
 func InverseScreenSpace(width, height float64) Matrix {
-	return NewMatrix([4][4]float64{
-		{-2.0 / width, 0, 0, 1},
-		{0, -2.0 / height, 0, 1},
-		{0, 0, 1, 0},
-		{0, 0, 0, 1},
-	})
+	return DefaultConventions().InverseScreenSpace(width, height)
 }
 
 func (m1 Matrix) Determinant() float64 {
@@ -195,7 +195,7 @@ func TestTranslate(t *testing.T) {
 
 func TestRotationX(t *testing.T) {
 	type args struct {
-		angle float64
+		angle Radians
 	}
 	tests := []struct {
 		name string
@@ -219,7 +219,7 @@ func TestRotationX(t *testing.T) {
 
 func TestRotationY(t *testing.T) {
 	type args struct {
-		angle float64
+		angle Radians
 	}
 	tests := []struct {
 		name string
@@ -0,0 +1,137 @@
+package vectozavr
+
+import (
+	"fmt"
+	"math"
+)
+
+// A quaternion W + Xi + Yj + Zk; unit quaternions represent rotations
+type Quat struct {
+	W, X, Y, Z float64
+}
+
+// Creates a new quaternion with the given components
+func NewQuat(w, x, y, z float64) Quat {
+	return Quat{W: w, X: x, Y: y, Z: z}
+}
+
+// The quaternion of the zero rotation
+func IdentityQuat() Quat {
+	return Quat{W: 1}
+}
+
+// Rotation by the angle around the axis; a zero axis gives the identity
+func QuatAxisAngle(axis Vec3, angle Radians) Quat {
+	n, err := axis.Normalize()
+	if err != nil {
+		return IdentityQuat()
+	}
+	s := (angle / 2).Sin()
+	return Quat{W: (angle / 2).Cos(), X: n.X * s, Y: n.Y * s, Z: n.Z * s}
+}
+
+// The Hamilton product: rotating by q2 and then by q
+func (q Quat) Mul(q2 Quat) Quat {
+	return Quat{
+		W: q.W*q2.W - q.X*q2.X - q.Y*q2.Y - q.Z*q2.Z,
+		X: q.W*q2.X + q.X*q2.W + q.Y*q2.Z - q.Z*q2.Y,
+		Y: q.W*q2.Y - q.X*q2.Z + q.Y*q2.W + q.Z*q2.X,
+		Z: q.W*q2.Z + q.X*q2.Y - q.Y*q2.X + q.Z*q2.W,
+	}
+}
+
+// The conjugate quaternion, the inverse rotation for unit quaternions
+func (q Quat) Conj() Quat {
+	return Quat{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// The scalar product
+func (q Quat) Dot(q2 Quat) float64 {
+	return q.W*q2.W + q.X*q2.X + q.Y*q2.Y + q.Z*q2.Z
+}
+
+// Returns the length of the quaternion
+func (q Quat) Len() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+// Normalizing a quaternion
+func (q Quat) Normalize() (Quat, error) {
+	l := q.Len()
+	if l <= Zero*Zero {
+		return q, fmt.Errorf("cannot normalize: quaternion length is zero")
+	}
+	return Quat{W: q.W / l, X: q.X / l, Y: q.Y / l, Z: q.Z / l}, nil
+}
+
+// Rotates the vector by the unit quaternion
+func (q Quat) Rotate(v Vec3) Vec3 {
+	u := NewVec3(q.X, q.Y, q.Z)
+	t := u.Cross(v).Mul(2)
+	return v.Add(t.Mul(q.W)).Add(u.Cross(t))
+}
+
+// Returns the rotation matrix of the unit quaternion
+func (q Quat) Matrix() Matrix {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return NewMatrix([4][4]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// Extracts the rotation of an orthonormal upper-left 3x3 block as a unit quaternion
+func QuatFromMatrix(m Matrix) Quat {
+	a := m.m
+	var q Quat
+	tr := a[0][0] + a[1][1] + a[2][2]
+	switch {
+	case tr > 0:
+		s := 2 * math.Sqrt(tr+1)
+		q = Quat{W: s / 4, X: (a[2][1] - a[1][2]) / s, Y: (a[0][2] - a[2][0]) / s, Z: (a[1][0] - a[0][1]) / s}
+	case a[0][0] > a[1][1] && a[0][0] > a[2][2]:
+		s := 2 * math.Sqrt(1+a[0][0]-a[1][1]-a[2][2])
+		q = Quat{W: (a[2][1] - a[1][2]) / s, X: s / 4, Y: (a[0][1] + a[1][0]) / s, Z: (a[0][2] + a[2][0]) / s}
+	case a[1][1] > a[2][2]:
+		s := 2 * math.Sqrt(1+a[1][1]-a[0][0]-a[2][2])
+		q = Quat{W: (a[0][2] - a[2][0]) / s, X: (a[0][1] + a[1][0]) / s, Y: s / 4, Z: (a[1][2] + a[2][1]) / s}
+	default:
+		s := 2 * math.Sqrt(1+a[2][2]-a[0][0]-a[1][1])
+		q = Quat{W: (a[1][0] - a[0][1]) / s, X: (a[0][2] + a[2][0]) / s, Y: (a[1][2] + a[2][1]) / s, Z: s / 4}
+	}
+	if n, err := q.Normalize(); err == nil {
+		return n
+	}
+	return IdentityQuat()
+}
+
+// Spherical linear interpolation between unit quaternions along the shortest arc
+func Slerp(a, b Quat, t float64) Quat {
+	d := a.Dot(b)
+	if d < 0 {
+		b = Quat{W: -b.W, X: -b.X, Y: -b.Y, Z: -b.Z}
+		d = -d
+	}
+	var ka, kb float64
+	if d > 0.9995 {
+		// Nearly parallel: fall back to a normalised lerp
+		ka, kb = 1-t, t
+	} else {
+		theta := math.Acos(d)
+		s := math.Sin(theta)
+		ka = math.Sin((1-t)*theta) / s
+		kb = math.Sin(t*theta) / s
+	}
+	q := Quat{
+		W: ka*a.W + kb*b.W,
+		X: ka*a.X + kb*b.X,
+		Y: ka*a.Y + kb*b.Y,
+		Z: ka*a.Z + kb*b.Z,
+	}
+	if n, err := q.Normalize(); err == nil {
+		return n
+	}
+	return a
+}
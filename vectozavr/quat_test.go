@@ -0,0 +1,107 @@
+package vectozavr
+
+import (
+	"math"
+	"testing"
+)
+
+const eps = 1e-9
+
+func vec3Near(a, b Vec3) bool {
+	return math.Abs(a.X-b.X) < eps && math.Abs(a.Y-b.Y) < eps && math.Abs(a.Z-b.Z) < eps
+}
+
+func matrixNear(a, b Matrix) bool {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			if math.Abs(a.m[i][j]-b.m[i][j]) > eps {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestQuat_Rotate(t *testing.T) {
+	type args struct {
+		axis  Vec3
+		angle Radians
+		v     Vec3
+	}
+	tests := []struct {
+		name string
+		args args
+		want Vec3
+	}{
+		{
+			name: "testRotateZ",
+			args: args{axis: Vec3{0, 0, 1}, angle: math.Pi / 2, v: Vec3{1, 0, 0}},
+			want: Vec3{0, 1, 0},
+		},
+		{
+			name: "testRotateX",
+			args: args{axis: Vec3{2, 0, 0}, angle: math.Pi, v: Vec3{0, 1, 1}},
+			want: Vec3{0, -1, -1},
+		},
+		{
+			name: "testZeroAxis",
+			args: args{axis: Vec3{0, 0, 0}, angle: 1, v: Vec3{1, 2, 3}},
+			want: Vec3{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := QuatAxisAngle(tt.args.axis, tt.args.angle)
+			if got := q.Rotate(tt.args.v); !vec3Near(got, tt.want) {
+				t.Errorf("Quat.Rotate() = %v, want %v", got, tt.want)
+			}
+			if got := q.Matrix().Vec3Mul(tt.args.v); !vec3Near(got, tt.want) {
+				t.Errorf("Quat.Matrix().Vec3Mul() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuat_Matrix(t *testing.T) {
+	tests := []struct {
+		name string
+		q    Quat
+		want Matrix
+	}{
+		{name: "testRotationV", q: QuatAxisAngle(Vec3{1, 2, 3}, 0.7), want: RotationV(Vec3{1, 2, 3}, 0.7)},
+		{name: "testRotationX", q: QuatAxisAngle(Vec3{1, 0, 0}, 1), want: RotationX(1)},
+		{name: "testIdentity", q: IdentityQuat(), want: Identity()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.q.Matrix(); !matrixNear(got, tt.want) {
+				t.Errorf("Quat.Matrix() = %v, want %v", got, tt.want)
+			}
+			back := QuatFromMatrix(tt.want)
+			if math.Abs(math.Abs(back.Dot(tt.q))-1) > eps {
+				t.Errorf("QuatFromMatrix() = %v, want ±%v", back, tt.q)
+			}
+		})
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	a := IdentityQuat()
+	b := QuatAxisAngle(Vec3{0, 1, 0}, math.Pi/2)
+	tests := []struct {
+		name string
+		t    float64
+		want Quat
+	}{
+		{name: "testStart", t: 0, want: a},
+		{name: "testHalf", t: 0.5, want: QuatAxisAngle(Vec3{0, 1, 0}, math.Pi/4)},
+		{name: "testEnd", t: 1, want: b},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Slerp(a, b, tt.t); math.Abs(got.Dot(tt.want)-1) > eps {
+				t.Errorf("Slerp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package vectozavr
+
+import (
+	"math"
+)
+
+// A half-line from Origin along Direction
+type Ray struct {
+	Origin    Vec3
+	Direction Vec3
+}
+
+// Creates a ray with a normalised direction
+func NewRay(origin, direction Vec3) Ray {
+	d, err := direction.Normalize()
+	if err != nil {
+		d = direction
+	}
+	return Ray{Origin: origin, Direction: d}
+}
+
+// Returns the point t units along the ray
+func (r Ray) At(t float64) Vec3 {
+	return r.Origin.Add(r.Direction.Mul(t))
+}
+
+// The plane of points p with Normal·p = D
+type Plane struct {
+	Normal Vec3
+	D      float64
+}
+
+// Creates the plane through the point with the given normal
+func NewPlane(normal, point Vec3) Plane {
+	n, err := normal.Normalize()
+	if err != nil {
+		n = normal
+	}
+	return Plane{Normal: n, D: n.Dot(point)}
+}
+
+// Signed distance from the plane to the point, positive on the side the normal points to
+func (p Plane) Distance(v Vec3) float64 {
+	return p.Normal.Dot(v) - p.D
+}
+
+// Returns where the ray crosses the plane. It reports false when the ray is
+// parallel to the plane or points away from it.
+func (p Plane) Intersect(r Ray) (Vec3, bool) {
+	denom := p.Normal.Dot(r.Direction)
+	if math.Abs(denom) < 1e-12 {
+		return Vec3{}, false
+	}
+	t := (p.D - p.Normal.Dot(r.Origin)) / denom
+	if t < 0 {
+		return Vec3{}, false
+	}
+	return r.At(t), true
+}
@@ -0,0 +1,32 @@
+package vectozavr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlane_Intersect(t *testing.T) {
+	plane := NewPlane(NewVec3(0, 2, 0), NewVec3(0, 1, 0))
+	tests := []struct {
+		name   string
+		ray    Ray
+		want   Vec3
+		wantOk bool
+	}{
+		{name: "testStraight", ray: NewRay(NewVec3(3, 5, -1), NewVec3(0, -1, 0)), want: NewVec3(3, 1, -1), wantOk: true},
+		{name: "testSlanted", ray: NewRay(NewVec3(0, 3, 0), NewVec3(1, -1, 2)), want: NewVec3(2, 1, 4), wantOk: true},
+		{name: "testParallel", ray: NewRay(NewVec3(0, 3, 0), NewVec3(1, 0, 0))},
+		{name: "testAway", ray: NewRay(NewVec3(0, 3, 0), NewVec3(0, 1, 0))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := plane.Intersect(tt.ray)
+			if ok != tt.wantOk || ok && !vec3Near(got, tt.want) {
+				t.Errorf("Plane.Intersect() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+	if d := plane.Distance(NewVec3(7, -2, 3)); math.Abs(d+3) > eps {
+		t.Errorf("Plane.Distance() = %v, want -3", d)
+	}
+}
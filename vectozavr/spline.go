@@ -0,0 +1,19 @@
+package vectozavr
+
+// Cubic Hermite curve from p0 to p1 with tangents m0 and m1, t in [0, 1]
+func Hermite(p0, m0, p1, m1 Vec3, t float64) Vec3 {
+	t2 := t * t
+	t3 := t2 * t
+	h00 := 2*t3 - 3*t2 + 1
+	h10 := t3 - 2*t2 + t
+	h01 := -2*t3 + 3*t2
+	h11 := t3 - t2
+	return p0.Mul(h00).Add(m0.Mul(h10)).Add(p1.Mul(h01)).Add(m1.Mul(h11))
+}
+
+// Uniform Catmull-Rom curve between p1 and p2, t in [0, 1]
+func CatmullRom(p0, p1, p2, p3 Vec3, t float64) Vec3 {
+	m1 := p2.Sub(p0).Mul(0.5)
+	m2 := p3.Sub(p1).Mul(0.5)
+	return Hermite(p1, m1, p2, m2, t)
+}
@@ -0,0 +1,35 @@
+package vectozavr
+
+import (
+	"testing"
+)
+
+func TestCatmullRom(t *testing.T) {
+	p0, p1, p2, p3 := Vec3{0, 0, 0}, Vec3{1, 0, 0}, Vec3{2, 1, 0}, Vec3{3, 1, 0}
+	tests := []struct {
+		name string
+		t    float64
+		want Vec3
+	}{
+		{name: "testStart", t: 0, want: p1},
+		{name: "testEnd", t: 1, want: p2},
+		{name: "testHalf", t: 0.5, want: Vec3{1.5, 0.5, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CatmullRom(p0, p1, p2, p3, tt.t); !vec3Near(got, tt.want) {
+				t.Errorf("CatmullRom() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHermite_Line(t *testing.T) {
+	// Tangents equal to the chord give uniform motion along the line
+	p0, p1 := Vec3{0, 0, 0}, Vec3{2, 4, 6}
+	for _, u := range []float64{0, 0.25, 0.5, 0.75, 1} {
+		if got, want := Hermite(p0, p1, p1, p1, u), p1.Mul(u); !vec3Near(got, want) {
+			t.Errorf("Hermite(%v) = %v, want %v", u, got, want)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package vectozavr
+
+import (
+	"errors"
+	"math"
+)
+
+// The skew-symmetric matrix of the cross product: Skew(w)·v = w × v
+func Skew(w Vec3) Matrix {
+	return NewMatrix([4][4]float64{
+		{0, -w.Z, w.Y, 0},
+		{w.Z, 0, -w.X, 0},
+		{-w.Y, w.X, 0, 0},
+		{0, 0, 0, 0},
+	})
+}
+
+// The generator of a motion (a twist): rotation w and velocity v
+func Twist(w, v Vec3) Matrix {
+	t := Skew(w)
+	t.m[0][3], t.m[1][3], t.m[2][3] = v.X, v.Y, v.Z
+	return t
+}
+
+// Splits a twist back into its rotation and velocity
+func (m Matrix) Untwist() (w, v Vec3) {
+	w = NewVec3(m.m[2][1], m.m[0][2], m.m[1][0])
+	return w, m.W()
+}
+
+// The matrix exponential of a twist: a rotation (for v = 0) or a rigid motion
+func Exp(twist Matrix) Matrix {
+	w, v := twist.Untwist()
+	theta := math.Sqrt(w.Dot(w))
+	W := Skew(w)
+	W2 := W.MatMul(W)
+
+	// Coefficients of the Rodrigues formula and their Taylor series near zero
+	var a, b, c float64
+	if theta < 1e-6 {
+		a = 1 - theta*theta/6
+		b = 0.5 - theta*theta/24
+		c = 1.0/6 - theta*theta/120
+	} else {
+		a = math.Sin(theta) / theta
+		b = (1 - math.Cos(theta)) / (theta * theta)
+		c = (theta - math.Sin(theta)) / (theta * theta * theta)
+	}
+
+	r := Identity().add(W.scale(a)).add(W2.scale(b))
+	V := Identity().add(W.scale(b)).add(W2.scale(c))
+	t := V.Vec3Mul(v)
+	r.m[0][3], r.m[1][3], r.m[2][3] = t.X, t.Y, t.Z
+	return r
+}
+
+// The matrix logarithm of a rotation or a rigid motion, the inverse of Exp.
+// Returns an error if the matrix is not a rigid motion.
+func Log(m Matrix) (Matrix, error) {
+	if !m.IsRigid() {
+		return ZeroMatrix(), errors.New("matrix is not a rigid motion")
+	}
+	r := m.rotationPart()
+	cosTheta := math.Max(-1, math.Min(1, (r.m[0][0]+r.m[1][1]+r.m[2][2]-1)/2))
+	theta := math.Acos(cosTheta)
+
+	var w Vec3
+	switch {
+	case theta < 1e-6:
+		w = NewVec3(r.m[2][1]-r.m[1][2], r.m[0][2]-r.m[2][0], r.m[1][0]-r.m[0][1]).Mul(0.5)
+	case math.Pi-theta < 1e-6:
+		// Near π the sine is too small, so the axis comes from the quaternion
+		q := QuatFromMatrix(r)
+		axis, err := NewVec3(q.X, q.Y, q.Z).Normalize()
+		if err != nil {
+			return ZeroMatrix(), err
+		}
+		w = axis.Mul(theta)
+	default:
+		k := theta / (2 * math.Sin(theta))
+		w = NewVec3(r.m[2][1]-r.m[1][2], r.m[0][2]-r.m[2][0], r.m[1][0]-r.m[0][1]).Mul(k)
+	}
+
+	W := Skew(w)
+	W2 := W.MatMul(W)
+	var c float64
+	if theta < 1e-6 {
+		c = 1.0 / 12
+	} else {
+		c = (1 - theta*math.Sin(theta)/(2*(1-math.Cos(theta)))) / (theta * theta)
+	}
+	invV := Identity().add(W.scale(-0.5)).add(W2.scale(c))
+	return Twist(w, invV.Vec3Mul(m.W())), nil
+}
+
+// Reports whether the matrix is a rotation with a translation (orthonormal, det = 1)
+func (m Matrix) IsRigid() bool {
+	const eps = 1e-6
+	if math.Abs(m.m[3][0]) > eps || math.Abs(m.m[3][1]) > eps || math.Abs(m.m[3][2]) > eps || math.Abs(m.m[3][3]-1) > eps {
+		return false
+	}
+	x, y, z := m.X(), m.Y(), m.Z()
+	if math.Abs(x.Dot(x)-1) > eps || math.Abs(y.Dot(y)-1) > eps || math.Abs(z.Dot(z)-1) > eps {
+		return false
+	}
+	if math.Abs(x.Dot(y)) > eps || math.Abs(y.Dot(z)) > eps || math.Abs(z.Dot(x)) > eps {
+		return false
+	}
+	return x.Cross(y).Dot(z) > 0
+}
+
+// Splits the matrix into a translation, a rotation and a scale (M = T·R·S).
+// Shear is not supported and is lost.
+func Decompose(m Matrix) (translation Vec3, rotation Quat, scale Vec3, err error) {
+	x, y, z := m.X(), m.Y(), m.Z()
+	sx, _ := x.Len()
+	sy, _ := y.Len()
+	sz, _ := z.Len()
+	if sx < 1e-12 || sy < 1e-12 || sz < 1e-12 {
+		return Vec3{}, IdentityQuat(), Vec3{}, errors.New("matrix is singular")
+	}
+	// A reflection goes into the X scale so that the rotation stays proper
+	if x.Cross(y).Dot(z) < 0 {
+		sx = -sx
+	}
+	r := NewMatrixVec3(x.Mul(1/sx), y.Mul(1/sy), z.Mul(1/sz))
+	return m.W(), QuatFromMatrix(r), NewVec3(sx, sy, sz), nil
+}
+
+// Builds a matrix from a translation, a rotation and a scale (M = T·R·S)
+func Compose(translation Vec3, rotation Quat, scale Vec3) Matrix {
+	return Translation(translation).MatMul(rotation.Matrix()).MatMul(Scale(scale))
+}
+
+// Smooth interpolation between two transforms: the translation and the scale
+// are interpolated linearly, the rotation along the shortest arc (slerp).
+// If either matrix cannot be decomposed, the nearer of a and b is returned.
+func InterpolateTransform(a, b Matrix, t float64) Matrix {
+	ta, ra, sa, errA := Decompose(a)
+	tb, rb, sb, errB := Decompose(b)
+	if errA != nil || errB != nil {
+		if t < 0.5 {
+			return a
+		}
+		return b
+	}
+	return Compose(
+		ta.Add(tb.Sub(ta).Mul(t)),
+		Slerp(ra, rb, t),
+		sa.Add(sb.Sub(sa).Mul(t)),
+	)
+}
+
+// Element-wise sum of the matrices
+func (m Matrix) add(n Matrix) Matrix {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			m.m[i][j] += n.m[i][j]
+		}
+	}
+	return m
+}
+
+// Multiplies the matrix by a number
+func (m Matrix) scale(k float64) Matrix {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 4; j++ {
+			m.m[i][j] *= k
+		}
+	}
+	return m
+}
+
+// The upper-left 3x3 block, without the translation
+func (m Matrix) rotationPart() Matrix {
+	return NewMatrixVec3(m.X(), m.Y(), m.Z())
+}
@@ -0,0 +1,92 @@
+package vectozavr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExp(t *testing.T) {
+	tests := []struct {
+		name  string
+		twist Matrix
+		want  Matrix
+	}{
+		{name: "testZero", twist: ZeroMatrix(), want: Identity()},
+		{name: "testRotation", twist: Skew(Vec3{0, 0, 1}), want: RotationZ(1)},
+		{name: "testAxisAngle", twist: Skew(Vec3{1, 2, 3}.Mul(0.2)), want: RotationV(Vec3{1, 2, 3}, Radians(0.2*math.Sqrt(14)))},
+		{name: "testTranslation", twist: Twist(Vec3{}, Vec3{1, 2, 3}), want: Translation(Vec3{1, 2, 3})},
+		// Screw motion: half a turn around Z while sliding 2 along it
+		{name: "testScrew", twist: Twist(Vec3{0, 0, math.Pi}, Vec3{0, 0, 2}), want: Translation(Vec3{0, 0, 2}).MatMul(RotationZ(math.Pi))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Exp(tt.twist); !matrixNear(got, tt.want) {
+				t.Errorf("Exp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       Matrix
+		wantErr bool
+	}{
+		{name: "testIdentity", m: Identity()},
+		{name: "testRotation", m: RotationV(Vec3{1, -1, 2}, 1.3)},
+		{name: "testHalfTurn", m: RotationY(math.Pi)},
+		{name: "testRigid", m: Translation(Vec3{3, -2, 1}).MatMul(Rotation(Vec3{0.3, 0.5, -0.2}))},
+		{name: "testScaled", m: Scale(Vec3{2, 2, 2}), wantErr: true},
+		{name: "testProjective", m: Projection(60, 1, 1, 10), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Log(tt.m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Log() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !matrixNear(Exp(got), tt.m) {
+				t.Errorf("Exp(Log()) = %v, want %v", Exp(got), tt.m)
+			}
+		})
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	tr := Vec3{1, 2, 3}
+	rot := QuatAxisAngle(Vec3{1, 1, 0}, 0.8)
+	sc := Vec3{2, 0.5, 3}
+	gotT, gotR, gotS, err := Decompose(Compose(tr, rot, sc))
+	if err != nil {
+		t.Fatalf("Decompose() error = %v", err)
+	}
+	if !vec3Near(gotT, tr) || !vec3Near(gotS, sc) || math.Abs(math.Abs(gotR.Dot(rot))-1) > eps {
+		t.Errorf("Decompose() = %v, %v, %v, want %v, %v, %v", gotT, gotR, gotS, tr, rot, sc)
+	}
+	if _, _, _, err := Decompose(ZeroMatrix()); err == nil {
+		t.Errorf("Decompose(ZeroMatrix()) error = nil, want error")
+	}
+}
+
+func TestInterpolateTransform(t *testing.T) {
+	a := Translation(Vec3{0, 0, 0})
+	b := Translation(Vec3{4, 0, 0}).MatMul(RotationZ(math.Pi / 2)).MatMul(Scale(Vec3{3, 3, 3}))
+	tests := []struct {
+		name string
+		t    float64
+		want Matrix
+	}{
+		{name: "testStart", t: 0, want: a},
+		{name: "testEnd", t: 1, want: b},
+		{name: "testHalf", t: 0.5, want: Translation(Vec3{2, 0, 0}).MatMul(RotationZ(math.Pi / 4)).MatMul(Scale(Vec3{2, 2, 2}))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InterpolateTransform(a, b, tt.t); !matrixNear(got, tt.want) {
+				t.Errorf("InterpolateTransform() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}